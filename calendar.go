@@ -0,0 +1,183 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Holiday divisions of the trading calendar.
+const (
+	NonBusinessDay = "0"
+	BusinessDay    = "1"
+	// HalfDayTrading marks the half-day sessions TSE held until 2011.
+	HalfDayTrading = "2"
+	// NonBusinessDayWithHolidayTrading marks holidays on which derivatives
+	// are traded.
+	NonBusinessDayWithHolidayTrading = "3"
+)
+
+// ErrOutsideCalendar is returned for dates the calendar does not cover.
+var ErrOutsideCalendar = errors.New("jquants: date outside of the trading calendar")
+
+const (
+	calendarCacheFile = "trading_calendar.json"
+	calendarCacheTTL  = 24 * time.Hour
+)
+
+// TradingCalendarDay is a day of the TSE trading calendar.
+type TradingCalendarDay struct {
+	Date            Date   `json:"Date"`
+	HolidayDivision string `json:"HolidayDivision"`
+}
+
+// IsTradingDay reports whether equities are traded on the day.
+func (d TradingCalendarDay) IsTradingDay() bool {
+	return d.HolidayDivision == BusinessDay || d.HolidayDivision == HalfDayTrading
+}
+
+var tradingCalendarEndpoint = endpoint[TradingCalendarDay]{"/markets/trading_calendar", "trading_calendar"}
+
+// TradingCalendarRequest selects calendar days. All fields are optional.
+type TradingCalendarRequest struct {
+	HolidayDivision string
+	From            time.Time
+	To              time.Time
+}
+
+// Validate reports invalid parameters.
+func (r TradingCalendarRequest) Validate() error {
+	return validateDateRange(time.Time{}, r.From, r.To)
+}
+
+func (r TradingCalendarRequest) query() url.Values {
+	query := url.Values{}
+	setString(query, "holidaydivision", r.HolidayDivision)
+	setDate(query, "from", r.From)
+	setDate(query, "to", r.To)
+	return query
+}
+
+// TradingCalendar returns the calendar days selected by req.
+func (c *Client) TradingCalendar(ctx context.Context, req TradingCalendarRequest) ([]TradingCalendarDay, error) {
+	return tradingCalendarEndpoint.list(ctx, c, req)
+}
+
+// calendarCache is the content of the calendar cache file.
+type calendarCache struct {
+	FetchedAt       time.Time            `json:"fetched_at"`
+	TradingCalendar []TradingCalendarDay `json:"trading_calendar"`
+}
+
+// Calendar returns the complete trading calendar. The calendar is cached in
+// the client's cache directory and fetched again once a day; when that
+// fails, e.g. because the machine is offline, the cached calendar is used
+// regardless of its age.
+func (c *Client) Calendar(ctx context.Context) (*Calendar, error) {
+	path, pathErr := c.cachePath(calendarCacheFile)
+	var cache calendarCache
+	if pathErr == nil {
+		if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &cache) == nil {
+			if time.Since(cache.FetchedAt) < calendarCacheTTL {
+				return NewCalendar(cache.TradingCalendar), nil
+			}
+		}
+	}
+
+	days, err := c.TradingCalendar(ctx, TradingCalendarRequest{})
+	if err != nil {
+		if len(cache.TradingCalendar) > 0 {
+			return NewCalendar(cache.TradingCalendar), nil
+		}
+		return nil, err
+	}
+	if pathErr == nil {
+		// the calendar is usable without a cache, so failing to write it is
+		// not an error
+		if data, err := json.Marshal(calendarCache{FetchedAt: time.Now(), TradingCalendar: days}); err == nil {
+			os.WriteFile(path, data, 0664)
+		}
+	}
+	return NewCalendar(days), nil
+}
+
+func (c *Client) cachePath(file string) (string, error) {
+	if c.cacheDir != "" {
+		return filepath.Join(c.cacheDir, file), os.MkdirAll(c.cacheDir, os.ModePerm)
+	}
+	return getConfigFile(file)
+}
+
+// Calendar answers trading day questions from a fetched trading calendar.
+type Calendar struct {
+	first, last Date
+	trading     map[Date]bool
+}
+
+// NewCalendar builds a calendar from the days of a trading calendar. The
+// calendar covers the range from the first to the last of days.
+func NewCalendar(days []TradingCalendarDay) *Calendar {
+	cal := &Calendar{trading: make(map[Date]bool, len(days))}
+	for _, day := range days {
+		if cal.first.IsZero() || day.Date.Before(cal.first) {
+			cal.first = day.Date
+		}
+		if day.Date.After(cal.last) {
+			cal.last = day.Date
+		}
+		cal.trading[day.Date] = day.IsTradingDay()
+	}
+	return cal
+}
+
+// Covers reports whether d lies within the calendar.
+func (cal *Calendar) Covers(d Date) bool {
+	return !cal.first.IsZero() && !d.Before(cal.first) && !d.After(cal.last)
+}
+
+// IsTradingDay reports whether equities are traded on d. Dates outside the
+// calendar are reported as non-trading days; use Covers to tell them apart.
+func (cal *Calendar) IsTradingDay(d Date) bool {
+	return cal.trading[d]
+}
+
+// NextTradingDay returns the first trading day after d.
+func (cal *Calendar) NextTradingDay(d Date) (Date, error) {
+	return cal.step(d, 1)
+}
+
+// PrevTradingDay returns the last trading day before d.
+func (cal *Calendar) PrevTradingDay(d Date) (Date, error) {
+	return cal.step(d, -1)
+}
+
+func (cal *Calendar) step(d Date, days int) (Date, error) {
+	for next := d.AddDays(days); cal.Covers(next); next = next.AddDays(days) {
+		if cal.trading[next] {
+			return next, nil
+		}
+	}
+	return Date{}, fmt.Errorf("%w: no trading day found from %s", ErrOutsideCalendar, d)
+}
+
+// TradingDaysBetween returns the trading days from from to to, both
+// inclusive, in order.
+func (cal *Calendar) TradingDaysBetween(from, to Date) ([]Date, error) {
+	if !cal.Covers(from) || !cal.Covers(to) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrOutsideCalendar, from, to)
+	}
+	var days []Date
+	for d := range cal.trading {
+		if cal.trading[d] && !d.Before(from) && !d.After(to) {
+			days = append(days, d)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days, nil
+}
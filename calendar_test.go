@@ -0,0 +1,113 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testTradingCalendar = `{"trading_calendar":[
+	{"Date":"2023-01-02","HolidayDivision":"0"},
+	{"Date":"2023-01-03","HolidayDivision":"0"},
+	{"Date":"2023-01-04","HolidayDivision":"1"},
+	{"Date":"2023-01-05","HolidayDivision":"1"},
+	{"Date":"2023-01-06","HolidayDivision":"1"},
+	{"Date":"2023-01-07","HolidayDivision":"0"},
+	{"Date":"2023-01-08","HolidayDivision":"0"},
+	{"Date":"2023-01-09","HolidayDivision":"3"},
+	{"Date":"2023-01-10","HolidayDivision":"1"}
+]}`
+
+func testCalendar(t *testing.T) *Calendar {
+	days := page[TradingCalendarDay]{key: tradingCalendarEndpoint.key}
+	if err := json.Unmarshal([]byte(testTradingCalendar), &days); err != nil {
+		t.Fatal(err)
+	}
+	return NewCalendar(days.Items)
+}
+
+func TestCalendar(t *testing.T) {
+	cal := testCalendar(t)
+	jan := func(day int) Date { return NewDate(2023, time.January, day) }
+
+	if !cal.IsTradingDay(jan(4)) || cal.IsTradingDay(jan(9)) || cal.IsTradingDay(jan(20)) {
+		t.Errorf("unexpected trading days")
+	}
+	if next, err := cal.NextTradingDay(jan(6)); err != nil || next != jan(10) {
+		t.Errorf("NextTradingDay = %v, %v", next, err)
+	}
+	if prev, err := cal.PrevTradingDay(jan(9)); err != nil || prev != jan(6) {
+		t.Errorf("PrevTradingDay = %v, %v", prev, err)
+	}
+	if _, err := cal.PrevTradingDay(jan(4)); !errors.Is(err, ErrOutsideCalendar) {
+		t.Errorf("expected ErrOutsideCalendar, got %v", err)
+	}
+	days, err := cal.TradingDaysBetween(jan(2), jan(9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(days) != "[2023-01-04 2023-01-05 2023-01-06]" {
+		t.Errorf("unexpected trading days %v", days)
+	}
+	if _, err := cal.TradingDaysBetween(jan(2), jan(20)); !errors.Is(err, ErrOutsideCalendar) {
+		t.Errorf("expected ErrOutsideCalendar, got %v", err)
+	}
+}
+
+func TestClientCalendarCache(t *testing.T) {
+	requests := 0
+	online := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets/trading_calendar" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		requests++
+		if !online {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(testTradingCalendar))
+	}))
+	defer server.Close()
+	cacheDir := t.TempDir()
+	client := NewClient(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource("test-token")), WithCacheDir(cacheDir))
+
+	for i := 0; i < 2; i++ {
+		cal, err := client.Calendar(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cal.IsTradingDay(NewDate(2023, time.January, 10)) {
+			t.Errorf("unexpected calendar")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second call to be served from cache, got %d requests", requests)
+	}
+
+	// an outdated cache is still used when the API cannot be reached
+	online = false
+	old := time.Now().Add(-2 * calendarCacheTTL)
+	path := filepath.Join(cacheDir, calendarCacheFile)
+	data, _ := os.ReadFile(path)
+	var cache calendarCache
+	json.Unmarshal(data, &cache)
+	cache.FetchedAt = old
+	data, _ = json.Marshal(cache)
+	os.WriteFile(path, data, 0664)
+
+	cal, err := client.Calendar(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 || !cal.Covers(NewDate(2023, time.January, 2)) {
+		t.Errorf("expected a failed refresh and the cached calendar, got %d requests", requests)
+	}
+}
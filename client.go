@@ -0,0 +1,228 @@
+package jquants_api_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client is a J-Quants API client. A Client is safe for concurrent use
+// and should be reused rather than created per call.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	tokens     TokenSource
+	userAgent  string
+	timeout    time.Duration
+	cacheDir   string
+	retry      RetryPolicy
+	limiter    *rateLimiter
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at another API root, e.g. a staging server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the underlying http.Client, e.g. to inject a transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokenSource sets where the client obtains its ID token from.
+func WithTokenSource(tokens TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits the time spent on a single HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithCacheDir sets the directory data such as the trading calendar is
+// cached in. It defaults to the local jquants configuration directory.
+func WithCacheDir(dir string) Option {
+	return func(c *Client) {
+		c.cacheDir = dir
+	}
+}
+
+// NewClient returns a client for the J-Quants API. Without options it talks
+// to BASE_URL and keeps its tokens fresh in the local jquants configuration.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:    BASE_URL,
+		httpClient: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tokens == nil {
+		c.tokens = NewRefreshingTokenSource(c, ConfigTokenStore())
+	}
+	if c.timeout > 0 {
+		// copy so that a caller supplied client is left untouched
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		c.httpClient = &httpClient
+	}
+	return c
+}
+
+// Authenticate exchanges login credentials for a refresh token.
+func (c *Client) Authenticate(ctx context.Context, login Login) (RefreshToken, error) {
+	var rt RefreshToken
+	data, err := json.Marshal(login)
+	if err != nil {
+		return rt, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, c.endpoint("/token/auth_user"), bytes.NewReader(data))
+	if err != nil {
+		return rt, err
+	}
+	if err = c.doJSON(req, &rt); err != nil {
+		return rt, err
+	}
+	rt.IssuedAt = time.Now()
+	return rt, nil
+}
+
+// RefreshIdToken exchanges a refresh token for an ID token.
+func (c *Client) RefreshIdToken(ctx context.Context, token RefreshToken) (IdToken, error) {
+	var it IdToken
+	u := fmt.Sprintf("%s?refreshtoken=%s", c.endpoint("/token/auth_refresh"), url.QueryEscape(token.RefreshToken))
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return it, err
+	}
+	if err = c.doJSON(req, &it); err != nil {
+		return it, err
+	}
+	it.IssuedAt = time.Now()
+	return it, nil
+}
+
+func (c *Client) endpoint(path string) string {
+	return c.baseURL + path
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	return req, nil
+}
+
+// doJSON sends req and decodes the JSON response body into v.
+func (c *Client) doJSON(req *http.Request, v interface{}) error {
+	if c.limiter != nil {
+		if err := c.limiter.wait(req.Context()); err != nil {
+			return err
+		}
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactURL(req.URL)
+		}
+		return err
+	}
+	defer res.Body.Close()
+	if err := checkResponse(res); err != nil {
+		return err
+	}
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("jquants: decoding response from %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+// get sends an authenticated GET request for path and decodes the response
+// into v. Failed requests are retried according to the client's retry
+// policy.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	url := c.endpoint(path)
+	if len(query) > 0 {
+		url += "?" + query.Encode()
+	}
+	for attempt := 1; ; attempt++ {
+		sent, err := c.getAuthorized(ctx, url, v)
+		if !sent {
+			// failures to obtain a token are not retried as GET requests
+			return err
+		}
+		delay, ok := c.retry.delay(ctx, attempt, err)
+		if !ok {
+			return err
+		}
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(attempt, err, delay)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// getAuthorized is like getOnce, but a request rejected with 401 is retried
+// once with a new ID token when the token source is able to replace it.
+func (c *Client) getAuthorized(ctx context.Context, url string, v interface{}) (bool, error) {
+	sent, err := c.getOnce(ctx, url, v)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+		if invalidator, ok := c.tokens.(tokenInvalidator); ok {
+			invalidator.Invalidate(apiErr.idToken)
+			sent, err = c.getOnce(ctx, url, v)
+		}
+	}
+	return sent, err
+}
+
+// getOnce sends a single GET request. It reports false when the request
+// could not be sent because no ID token was obtained.
+func (c *Client) getOnce(ctx context.Context, url string, v interface{}) (bool, error) {
+	idToken, err := c.tokens.Token(ctx)
+	if err != nil {
+		return false, err
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return true, err
+	}
+	req.Header.Set("Authorization", "Bearer "+idToken)
+	err = c.doJSON(req, v)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.idToken = idToken
+	}
+	return true, err
+}
@@ -0,0 +1,122 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client that sends its requests to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	opts = append([]Option{WithBaseURL(server.URL), WithTokenSource(StaticTokenSource("test-token"))}, opts...)
+	return NewClient(opts...)
+}
+
+func TestClientOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prices/daily_quotes" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "jquants-test" {
+			t.Errorf("unexpected User-Agent header %q", got)
+		}
+		w.Write([]byte(`{"daily_quotes":[{"Code":"86970","Date":"2022-09-30","Close":2020.0}]}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{}
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+		WithTokenSource(StaticTokenSource("test-token")),
+		WithUserAgent("jquants-test"),
+		WithTimeout(5*time.Second),
+	)
+	if httpClient.Timeout != 0 {
+		t.Errorf("WithTimeout modified the supplied http.Client")
+	}
+
+	quotes, err := client.Daily(context.Background(), testDailyRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 1 || quotes[0].Close.Float64 != 2020 {
+		t.Errorf("unexpected quotes %v", quotes)
+	}
+}
+
+func TestClientAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"This API requires at least 1 parameter as follows; 'date','code'."}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource("test-token")))
+	_, err := client.Daily(context.Background(), testDailyRequest)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("unexpected status code %d", apiErr.StatusCode)
+	}
+	if apiErr.Message != "This API requires at least 1 parameter as follows; 'date','code'." {
+		t.Errorf("unexpected message %q", apiErr.Message)
+	}
+	if !strings.HasPrefix(apiErr.URL, server.URL+"/prices/daily_quotes") {
+		t.Errorf("unexpected URL %q", apiErr.URL)
+	}
+}
+
+func TestClientNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource("test-token")))
+	if _, err := client.Daily(context.Background(), testDailyRequest); err == nil {
+		t.Errorf("expected an error from a closed server")
+	}
+}
+
+func TestClientNetworkErrorRedacted(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	_, err := client.RefreshIdToken(context.Background(), RefreshToken{RefreshToken: "SECRET-REFRESH"})
+	if err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if strings.Contains(err.Error(), "SECRET-REFRESH") || !strings.Contains(err.Error(), "REDACTED") {
+		t.Errorf("the refresh token should be redacted from %q", err)
+	}
+}
+
+func TestClientContextCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource("test-token")))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Daily(ctx, testDailyRequest)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package jquants_api_go
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"olympos.io/encoding/edn"
+)
+
+// JST is the time zone J-Quants dates refer to.
+var JST = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// Date is a calendar day in Japan, as used for trading and disclosure dates.
+// Dates are comparable with == and can be used as map keys. The zero Date
+// stands for a missing value.
+//
+// Dates encode as YYYY-MM-DD in JSON, EDN and text, and are parsed from
+// either YYYY-MM-DD or YYYYMMDD.
+type Date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// Deprecated: use Date.
+type JSONTime = Date
+
+// NewDate returns the date for year, month and day, normalizing values out
+// of range like time.Date does.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, JST))
+}
+
+// DateOf returns the date in Japan at the instant t.
+func DateOf(t time.Time) Date {
+	year, month, day := t.In(JST).Date()
+	return Date{year, month, day}
+}
+
+// Today returns the current date in Japan.
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+// ParseDate parses a date given as YYYY-MM-DD or YYYYMMDD. An empty string
+// yields the zero Date.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if t, err := time.ParseInLocation(layout, s, JST); err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("jquants: invalid date %q, expected YYYY-MM-DD or YYYYMMDD", s)
+}
+
+func (d Date) Year() int             { return d.year }
+func (d Date) Month() time.Month     { return d.month }
+func (d Date) Day() int              { return d.day }
+func (d Date) Weekday() time.Weekday { return d.Time().Weekday() }
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Time returns midnight of d in Japan.
+func (d Date) Time() time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, JST)
+}
+
+// AddDays returns d moved by n days.
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.year, d.month, d.day+n)
+}
+
+// Before reports whether d is before e.
+func (d Date) Before(e Date) bool {
+	if d.year != e.year {
+		return d.year < e.year
+	}
+	if d.month != e.month {
+		return d.month < e.month
+	}
+	return d.day < e.day
+}
+
+// After reports whether d is after e.
+func (d Date) After(e Date) bool {
+	return e.Before(d)
+}
+
+// String returns d as YYYY-MM-DD, or an empty string for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.year, d.month, d.day)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes d as a JSON string.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a date string; null, "" and "-", which J-Quants
+// uses for dates not determined yet, yield the zero Date.
+func (d *Date) UnmarshalJSON(buf []byte) error {
+	if string(buf) == "null" || string(buf) == `"-"` {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(buf, &s); err != nil {
+		return fmt.Errorf("jquants: invalid date %s: %w", buf, err)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalEDN encodes d as an EDN string.
+func (d Date) MarshalEDN() ([]byte, error) {
+	return edn.Marshal(d.String())
+}
+
+// UnmarshalEDN accepts a date string; nil and "" yield the zero Date.
+func (d *Date) UnmarshalEDN(buf []byte) error {
+	var s *string
+	if err := edn.Unmarshal(buf, &s); err != nil {
+		return fmt.Errorf("jquants: invalid date %s: %w", buf, err)
+	}
+	if s == nil {
+		*d = Date{}
+		return nil
+	}
+	return d.UnmarshalText([]byte(*s))
+}
@@ -0,0 +1,96 @@
+package jquants_api_go
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"olympos.io/encoding/edn"
+)
+
+func TestParseDate(t *testing.T) {
+	want := NewDate(2022, time.September, 30)
+	for _, s := range []string{"2022-09-30", "20220930"} {
+		d, err := ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d != want {
+			t.Errorf("ParseDate(%q) = %v, want %v", s, d, want)
+		}
+	}
+	for _, s := range []string{"2022/09/30", "20220931", "tomorrow"} {
+		if _, err := ParseDate(s); err == nil {
+			t.Errorf("ParseDate(%q) should fail", s)
+		}
+	}
+}
+
+func TestDateOfJST(t *testing.T) {
+	// 16:00 UTC is already the next day in Tokyo
+	d := DateOf(time.Date(2022, 9, 29, 16, 0, 0, 0, time.UTC))
+	if d != NewDate(2022, time.September, 30) {
+		t.Errorf("unexpected date %v", d)
+	}
+	if !d.Time().Equal(time.Date(2022, 9, 29, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected midnight %v", d.Time())
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	a := NewDate(2022, time.December, 31)
+	b := a.AddDays(1)
+	if b != NewDate(2023, time.January, 1) {
+		t.Errorf("unexpected date %v", b)
+	}
+	if !a.Before(b) || a.After(b) || !b.After(a) {
+		t.Errorf("unexpected order of %v and %v", a, b)
+	}
+	seen := map[Date]bool{a: true}
+	if !seen[NewDate(2022, time.December, 31)] {
+		t.Errorf("dates should work as map keys")
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	var v struct {
+		Date         Date
+		Empty        Date
+		Null         Date
+		Undetermined Date
+	}
+	if err := json.Unmarshal([]byte(`{"Date":"2022-09-30","Empty":"","Null":null,"Undetermined":"-"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Date != NewDate(2022, time.September, 30) || !v.Empty.IsZero() || !v.Null.IsZero() || !v.Undetermined.IsZero() {
+		t.Errorf("unexpected dates %+v", v)
+	}
+	out, err := json.Marshal(v.Date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `"2022-09-30"` {
+		t.Errorf("unexpected JSON %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"Date":"30/09/2022"}`), &v); err == nil {
+		t.Errorf("malformed dates should fail")
+	}
+}
+
+func TestDateEDN(t *testing.T) {
+	in := struct {
+		Date Date `edn:"date"`
+	}{NewDate(2022, time.September, 30)}
+	encoded, err := edn.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := in
+	out.Date = Date{}
+	if err := edn.Unmarshal(encoded, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("EDN round trip of %s gave %v", encoded, out.Date)
+	}
+}
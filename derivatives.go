@@ -0,0 +1,196 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Put/call divisions of an option.
+const (
+	Put  = "1"
+	Call = "2"
+)
+
+// SessionPrices are the four prices of a trading session. They are null
+// when there was no trade in the session.
+type SessionPrices struct {
+	Open  NullFloat64 `json:"Open"`
+	High  NullFloat64 `json:"High"`
+	Low   NullFloat64 `json:"Low"`
+	Close NullFloat64 `json:"Close"`
+}
+
+// DerivativeQuote holds the fields common to futures and options prices.
+// WholeDay covers the night, morning and day sessions of the trading day.
+type DerivativeQuote struct {
+	Date                           Date          `json:"Date"`
+	Code                           string        `json:"Code"`
+	ContractMonth                  string        `json:"ContractMonth"`
+	WholeDay                       SessionPrices `json:"-" flatten:"WholeDay"`
+	NightSession                   SessionPrices `json:"-" flatten:"NightSession"`
+	MorningSession                 SessionPrices `json:"-" flatten:"MorningSession"`
+	DaySession                     SessionPrices `json:"-" flatten:"DaySession"`
+	Volume                         float64       `json:"Volume"`
+	VolumeOnlyAuction              float64       `json:"Volume(OnlyAuction)"`
+	TurnoverValue                  float64       `json:"TurnoverValue"`
+	OpenInterest                   float64       `json:"OpenInterest"`
+	SettlementPrice                NullFloat64   `json:"SettlementPrice"`
+	LastTradingDay                 Date          `json:"LastTradingDay"`
+	SpecialQuotationDay            Date          `json:"SpecialQuotationDay"`
+	EmergencyMarginTriggerDivision string        `json:"EmergencyMarginTriggerDivision"`
+}
+
+// FutureQuote is the daily price of a futures contract.
+type FutureQuote struct {
+	DerivativeQuote
+	DerivativesProductCategory string `json:"DerivativesProductCategory"`
+	CentralContractMonthFlag   Flag   `json:"CentralContractMonthFlag"`
+}
+
+// UnmarshalJSON decodes the flat session keys such as NightSessionOpen.
+func (q *FutureQuote) UnmarshalJSON(data []byte) error {
+	type plain FutureQuote
+	if err := json.Unmarshal(data, (*plain)(q)); err != nil {
+		return err
+	}
+	return unflatten(data, q)
+}
+
+// MarshalJSON encodes q with the flat J-Quants keys.
+func (q FutureQuote) MarshalJSON() ([]byte, error) {
+	type plain FutureQuote
+	data, err := json.Marshal(plain(q))
+	if err != nil {
+		return nil, err
+	}
+	return flatten(data, q)
+}
+
+// OptionQuote is the daily price of an options contract. Index options
+// leave DerivativesProductCategory, UnderlyingSSO and
+// CentralContractMonthFlag empty.
+type OptionQuote struct {
+	DerivativeQuote
+	DerivativesProductCategory string      `json:"DerivativesProductCategory"`
+	StrikePrice                float64     `json:"StrikePrice"`
+	PutCallDivision            string      `json:"PutCallDivision"`
+	TheoreticalPrice           NullFloat64 `json:"TheoreticalPrice"`
+	BaseVolatility             NullFloat64 `json:"BaseVolatility"`
+	UnderlyingPrice            NullFloat64 `json:"UnderlyingPrice"`
+	ImpliedVolatility          NullFloat64 `json:"ImpliedVolatility"`
+	InterestRate               NullFloat64 `json:"InterestRate"`
+	UnderlyingSSO              string      `json:"UnderlyingSSO"`
+	CentralContractMonthFlag   Flag        `json:"CentralContractMonthFlag"`
+}
+
+// IsPut reports whether q is the price of a put option.
+func (q OptionQuote) IsPut() bool {
+	return q.PutCallDivision == Put
+}
+
+// IsCall reports whether q is the price of a call option.
+func (q OptionQuote) IsCall() bool {
+	return q.PutCallDivision == Call
+}
+
+// UnmarshalJSON decodes the flat session keys such as NightSessionOpen.
+func (q *OptionQuote) UnmarshalJSON(data []byte) error {
+	type plain OptionQuote
+	if err := json.Unmarshal(data, (*plain)(q)); err != nil {
+		return err
+	}
+	return unflatten(data, q)
+}
+
+// MarshalJSON encodes q with the flat J-Quants keys.
+func (q OptionQuote) MarshalJSON() ([]byte, error) {
+	type plain OptionQuote
+	data, err := json.Marshal(plain(q))
+	if err != nil {
+		return nil, err
+	}
+	return flatten(data, q)
+}
+
+var (
+	futuresEndpoint     = endpoint[FutureQuote]{"/derivatives/futures", "futures"}
+	optionsEndpoint     = endpoint[OptionQuote]{"/derivatives/options", "options"}
+	indexOptionEndpoint = endpoint[OptionQuote]{"/option/index_option", "index_option"}
+)
+
+func validateTradingDate(date time.Time) error {
+	if date.IsZero() {
+		return fmt.Errorf("%w: Date is required", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// DerivativesRequest selects the futures or options prices of a trading
+// day. Category narrows the result to one product, e.g. "TOPIXF" or
+// "NK225MWE"; Code selects the underlying of securities options and is
+// ignored for futures.
+type DerivativesRequest struct {
+	Date                     time.Time
+	Category                 string
+	Code                     string
+	CentralContractMonthOnly bool
+}
+
+// Validate reports invalid parameters.
+func (r DerivativesRequest) Validate() error {
+	return validateTradingDate(r.Date)
+}
+
+func (r DerivativesRequest) query() url.Values {
+	query := url.Values{}
+	setDate(query, "date", r.Date)
+	setString(query, "category", r.Category)
+	if r.CentralContractMonthOnly {
+		query.Set("contract_flag", "1")
+	}
+	return query
+}
+
+// optionsRequest adds the code parameter, which only the options endpoint
+// accepts.
+type optionsRequest struct {
+	DerivativesRequest
+}
+
+func (r optionsRequest) query() url.Values {
+	query := r.DerivativesRequest.query()
+	setString(query, "code", r.Code)
+	return query
+}
+
+type indexOptionsRequest struct {
+	date time.Time
+}
+
+func (r indexOptionsRequest) Validate() error {
+	return validateTradingDate(r.date)
+}
+
+func (r indexOptionsRequest) query() url.Values {
+	query := url.Values{}
+	setDate(query, "date", r.date)
+	return query
+}
+
+// Futures returns the futures prices selected by req.
+func (c *Client) Futures(ctx context.Context, req DerivativesRequest) ([]FutureQuote, error) {
+	return futuresEndpoint.list(ctx, c, req)
+}
+
+// Options returns the options prices selected by req.
+func (c *Client) Options(ctx context.Context, req DerivativesRequest) ([]OptionQuote, error) {
+	return optionsEndpoint.list(ctx, c, optionsRequest{req})
+}
+
+// IndexOptions returns the Nikkei 225 options prices on date.
+func (c *Client) IndexOptions(ctx context.Context, date time.Time) ([]OptionQuote, error) {
+	return indexOptionEndpoint.list(ctx, c, indexOptionsRequest{date})
+}
@@ -0,0 +1,87 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var testTradingDate = time.Date(2024, 1, 18, 0, 0, 0, 0, time.UTC)
+
+func TestFutures(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/derivatives/futures" || query.Get("category") != "TOPIXF" || query.Get("contract_flag") != "1" || query.Has("code") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"futures":[{"Code":"169090005","DerivativesProductCategory":"TOPIXF","Date":"2024-01-18","WholeDayOpen":2450.0,"WholeDayHigh":2460.5,"WholeDayLow":2440.0,"WholeDayClose":2455.0,"MorningSessionOpen":"","MorningSessionHigh":"","MorningSessionLow":"","MorningSessionClose":"","NightSessionOpen":2450.0,"NightSessionHigh":2452.0,"NightSessionLow":2441.0,"NightSessionClose":2445.5,"DaySessionOpen":2446.0,"DaySessionHigh":2460.5,"DaySessionLow":2440.0,"DaySessionClose":2455.0,"Volume":65443.0,"OpenInterest":474920.0,"TurnoverValue":1604001235000.0,"ContractMonth":"2024-03","Volume(OnlyAuction)":4300.0,"EmergencyMarginTriggerDivision":"002","LastTradingDay":"2024-03-07","SpecialQuotationDay":"2024-03-08","SettlementPrice":2455.0,"CentralContractMonthFlag":"1"}]}`))
+	})
+
+	quotes, err := client.Futures(context.Background(), DerivativesRequest{Date: testTradingDate, Category: "TOPIXF", Code: "ignored", CentralContractMonthOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 1 {
+		t.Fatalf("expected one quote, got %d", len(quotes))
+	}
+	q := quotes[0]
+	if q.NightSession.Close != NewNullFloat64(2445.5) || q.WholeDay.High != NewNullFloat64(2460.5) || q.MorningSession.Open.Valid {
+		t.Errorf("unexpected session prices %+v", q)
+	}
+	if q.VolumeOnlyAuction != 4300 || !q.CentralContractMonthFlag || q.SpecialQuotationDay != NewDate(2024, time.March, 8) {
+		t.Errorf("unexpected quote %+v", q)
+	}
+
+	encoded, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again FutureQuote
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatal(err)
+	}
+	if again != q {
+		t.Errorf("round trip changed %+v into %+v", q, again)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/derivatives/options" || r.URL.Query().Get("code") != "7203" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"options":[{"Code":"140014505","DerivativesProductCategory":"EQOP","UnderlyingSSO":"7203","Date":"2024-01-18","WholeDayOpen":0.0,"WholeDayHigh":0.0,"WholeDayLow":0.0,"WholeDayClose":0.0,"DaySessionOpen":0.0,"DaySessionHigh":0.0,"DaySessionLow":0.0,"DaySessionClose":0.0,"NightSessionOpen":"","NightSessionHigh":"","NightSessionLow":"","NightSessionClose":"","Volume":0.0,"OpenInterest":330.0,"TurnoverValue":0.0,"ContractMonth":"2024-02","StrikePrice":2900.0,"PutCallDivision":"2","TheoreticalPrice":43.0,"BaseVolatility":31.5,"UnderlyingPrice":2830.5,"ImpliedVolatility":"","InterestRate":0.1,"SettlementPrice":43.0,"CentralContractMonthFlag":"0"}]}`))
+	})
+
+	quotes, err := client.Options(context.Background(), DerivativesRequest{Date: testTradingDate, Code: "7203"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 1 || !quotes[0].IsCall() || quotes[0].StrikePrice != 2900 || quotes[0].ImpliedVolatility.Valid || quotes[0].NightSession.Close.Valid {
+		t.Errorf("unexpected quotes %+v", quotes)
+	}
+}
+
+func TestIndexOptions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/option/index_option" || r.URL.Query().Get("date") != "20240118" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"index_option":[{"Date":"2024-01-18","Code":"130060018","WholeDayOpen":0.0,"WholeDayHigh":0.0,"WholeDayLow":0.0,"WholeDayClose":0.0,"NightSessionOpen":0.0,"NightSessionHigh":0.0,"NightSessionLow":0.0,"NightSessionClose":0.0,"DaySessionOpen":0.0,"DaySessionHigh":0.0,"DaySessionLow":0.0,"DaySessionClose":0.0,"Volume":0.0,"Volume(OnlyAuction)":0.0,"TurnoverValue":0.0,"OpenInterest":0.0,"ContractMonth":"2025-06","StrikePrice":20000.0,"EmergencyMarginTriggerDivision":"002","PutCallDivision":"1","LastTradingDay":"2025-06-12","SpecialQuotationDay":"2025-06-13","SettlementPrice":980.0,"TheoreticalPrice":974.641,"BaseVolatility":17.93025,"UnderlyingPrice":27527.64,"ImpliedVolatility":23.1816,"InterestRate":0.2336}]}`))
+	})
+
+	quotes, err := client.IndexOptions(context.Background(), testTradingDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 1 || !quotes[0].IsPut() || quotes[0].ImpliedVolatility != NewNullFloat64(23.1816) {
+		t.Errorf("unexpected quotes %+v", quotes)
+	}
+
+	if _, err := client.IndexOptions(context.Background(), time.Time{}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// request is implemented by the parameters of an endpoint.
+type request interface {
+	Validate() error
+	query() url.Values
+}
+
+// noParams is the request of endpoints without parameters.
+type noParams struct{}
+
+func (noParams) Validate() error {
+	return nil
+}
+
+func (noParams) query() url.Values {
+	return url.Values{}
+}
+
+// endpoint declares a paginated GET endpoint. Every response is a JSON
+// object holding the items of type T in the array named key, and
+// pagination_key when more pages follow.
+type endpoint[T any] struct {
+	path string
+	key  string
+}
+
+// list validates req and fetches all pages of the result. On error the
+// items fetched so far are returned.
+func (e endpoint[T]) list(ctx context.Context, c *Client, req request) ([]T, error) {
+	var items []T
+	pages := e.pages(ctx, c, req)
+	for pages.Next() {
+		items = append(items, pages.Items()...)
+	}
+	return items, pages.Err()
+}
+
+// pages validates req and returns a pager over the result. An invalid
+// request is reported by Err.
+func (e endpoint[T]) pages(ctx context.Context, c *Client, req request) *Pager[T] {
+	return &Pager[T]{
+		client: c,
+		ctx:    ctx,
+		path:   e.path,
+		query:  req.query(),
+		page:   page[T]{key: e.key},
+		err:    req.Validate(),
+	}
+}
+
+// page is one response of an endpoint.
+type page[T any] struct {
+	key           string
+	Items         []T
+	PaginationKey string
+}
+
+func (p *page[T]) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if items, ok := raw[p.key]; ok {
+		if err := json.Unmarshal(items, &p.Items); err != nil {
+			return err
+		}
+	}
+	if key, ok := raw["pagination_key"]; ok {
+		return json.Unmarshal(key, &p.PaginationKey)
+	}
+	return nil
+}
+
+// Pager iterates over the pages of a result:
+//
+//	for pages.Next() {
+//		for _, item := range pages.Items() {
+//			...
+//		}
+//	}
+//	if err := pages.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	client *Client
+	ctx    context.Context
+	path   string
+	query  url.Values
+
+	page    page[T]
+	started bool
+	err     error
+}
+
+// Next fetches the next page and reports whether there was one.
+func (p *Pager[T]) Next() bool {
+	if p.err != nil || (p.started && p.page.PaginationKey == "") {
+		return false
+	}
+	if p.started {
+		p.query.Set("pagination_key", p.page.PaginationKey)
+	}
+	p.started = true
+
+	next := page[T]{key: p.page.key}
+	if p.err = p.client.get(p.ctx, p.path, p.query, &next); p.err != nil {
+		return false
+	}
+	p.page = next
+	return true
+}
+
+// Items returns the items of the current page.
+func (p *Pager[T]) Items() []T {
+	return p.page.Items
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
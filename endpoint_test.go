@@ -0,0 +1,66 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type testItem struct {
+	Value int `json:"Value"`
+}
+
+var testEndpoint = endpoint[testItem]{"/test/items", "items"}
+
+type testRequest struct {
+	err error
+}
+
+func (r testRequest) Validate() error {
+	return r.err
+}
+
+func (r testRequest) query() url.Values {
+	return url.Values{"code": {"7203"}}
+}
+
+func TestEndpointList(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/test/items" || r.URL.Query().Get("code") != "7203" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch r.URL.Query().Get("pagination_key") {
+		case "":
+			w.Write([]byte(`{"items":[{"Value":1},{"Value":2}],"pagination_key":"second"}`))
+		case "second":
+			// pages may lack the items key altogether
+			w.Write([]byte(`{"pagination_key":"third"}`))
+		case "third":
+			w.Write([]byte(`{"items":[{"Value":3}]}`))
+		}
+	})
+
+	items, err := testEndpoint.list(context.Background(), client, testRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[2].Value != 3 || requests != 3 {
+		t.Errorf("unexpected items %+v after %d requests", items, requests)
+	}
+}
+
+func TestEndpointInvalidRequest(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("invalid requests should not be sent")
+	})
+
+	invalid := errors.New("invalid")
+	pages := testEndpoint.pages(context.Background(), client, testRequest{err: invalid})
+	if pages.Next() || pages.Err() != invalid {
+		t.Errorf("expected the validation error, got %v", pages.Err())
+	}
+}
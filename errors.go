@@ -0,0 +1,70 @@
+package jquants_api_go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrInvalidRequest is wrapped by the errors returned for request
+// parameters that are rejected before anything is sent to the API.
+var ErrInvalidRequest = errors.New("jquants: invalid request")
+
+// APIError is returned when the J-Quants API answers with a non-2xx status.
+// Use errors.As to inspect it.
+type APIError struct {
+	StatusCode int
+	// Message is the "message" field of the J-Quants error body, if any.
+	Message string
+	URL     string
+	// RetryAfter is the wait requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+
+	// idToken is the token the failed request was sent with.
+	idToken string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("jquants: %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("jquants: %s: %d %s", e.URL, e.StatusCode, e.Message)
+}
+
+// checkResponse returns an *APIError for a non-2xx response.
+func checkResponse(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		URL:        redactURL(res.Request.URL),
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err == nil {
+		var msg struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &msg) == nil {
+			apiErr.Message = msg.Message
+		}
+	}
+	return apiErr
+}
+
+// redactURL hides the refresh token passed as a query parameter.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if q.Get("refreshtoken") == "" {
+		return u.String()
+	}
+	q.Set("refreshtoken", "REDACTED")
+	redacted := *u
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	jquants "github.com/hellonico/jquants-api-go"
+	"log"
 )
 
 func main() {
@@ -25,16 +26,23 @@ func main() {
 	//	os.Exit(0)
 	//}
 	if *refreshToken {
-		jquants.GetRefreshToken()
+		if _, err := jquants.GetRefreshToken(); err != nil {
+			log.Fatal(err)
+		}
 	}
 	if *refreshId {
-		jquants.GetIdToken()
+		if _, err := jquants.GetIdToken(); err != nil {
+			log.Fatal(err)
+		}
 	}
 
-	var quotes = jquants.Daily(*code, *date, *from, *to)
+	quotes, err := jquants.Daily(*code, *date, *from, *to)
+	if err != nil {
+		log.Fatal(err)
+	}
 	fmt.Printf("[%d] Daily Quotes for %s \n", len(quotes.DailyQuotes), *code)
 	for _, quote := range quotes.DailyQuotes {
-		fmt.Printf("%s,%f\n", quote.Date, quote.Close)
+		fmt.Printf("%s,%s\n", quote.Date, quote.Close)
 	}
 
 }
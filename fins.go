@@ -0,0 +1,288 @@
+package jquants_api_go
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Statement is a summary of a financial results disclosure. J-Quants sends
+// the figures as strings, which are parsed into numbers; figures that do not
+// apply to the disclosure are null.
+type Statement struct {
+	DisclosedDate              Date   `json:"DisclosedDate"`
+	DisclosedTime              string `json:"DisclosedTime"`
+	LocalCode                  string `json:"LocalCode"`
+	DisclosureNumber           string `json:"DisclosureNumber"`
+	TypeOfDocument             string `json:"TypeOfDocument"`
+	TypeOfCurrentPeriod        string `json:"TypeOfCurrentPeriod"`
+	CurrentPeriodStartDate     Date   `json:"CurrentPeriodStartDate"`
+	CurrentPeriodEndDate       Date   `json:"CurrentPeriodEndDate"`
+	CurrentFiscalYearStartDate Date   `json:"CurrentFiscalYearStartDate"`
+	CurrentFiscalYearEndDate   Date   `json:"CurrentFiscalYearEndDate"`
+	NextFiscalYearStartDate    Date   `json:"NextFiscalYearStartDate"`
+	NextFiscalYearEndDate      Date   `json:"NextFiscalYearEndDate"`
+
+	NetSales                         NullFloat64 `json:"NetSales"`
+	OperatingProfit                  NullFloat64 `json:"OperatingProfit"`
+	OrdinaryProfit                   NullFloat64 `json:"OrdinaryProfit"`
+	Profit                           NullFloat64 `json:"Profit"`
+	EarningsPerShare                 NullFloat64 `json:"EarningsPerShare"`
+	DilutedEarningsPerShare          NullFloat64 `json:"DilutedEarningsPerShare"`
+	TotalAssets                      NullFloat64 `json:"TotalAssets"`
+	Equity                           NullFloat64 `json:"Equity"`
+	EquityToAssetRatio               NullFloat64 `json:"EquityToAssetRatio"`
+	BookValuePerShare                NullFloat64 `json:"BookValuePerShare"`
+	CashFlowsFromOperatingActivities NullFloat64 `json:"CashFlowsFromOperatingActivities"`
+	CashFlowsFromInvestingActivities NullFloat64 `json:"CashFlowsFromInvestingActivities"`
+	CashFlowsFromFinancingActivities NullFloat64 `json:"CashFlowsFromFinancingActivities"`
+	CashAndEquivalents               NullFloat64 `json:"CashAndEquivalents"`
+
+	ResultDividendPerShare1stQuarter    NullFloat64 `json:"ResultDividendPerShare1stQuarter"`
+	ResultDividendPerShare2ndQuarter    NullFloat64 `json:"ResultDividendPerShare2ndQuarter"`
+	ResultDividendPerShare3rdQuarter    NullFloat64 `json:"ResultDividendPerShare3rdQuarter"`
+	ResultDividendPerShareFiscalYearEnd NullFloat64 `json:"ResultDividendPerShareFiscalYearEnd"`
+	ResultDividendPerShareAnnual        NullFloat64 `json:"ResultDividendPerShareAnnual"`
+	ResultTotalDividendPaidAnnual       NullFloat64 `json:"ResultTotalDividendPaidAnnual"`
+	ResultPayoutRatioAnnual             NullFloat64 `json:"ResultPayoutRatioAnnual"`
+
+	ForecastDividendPerShare1stQuarter    NullFloat64 `json:"ForecastDividendPerShare1stQuarter"`
+	ForecastDividendPerShare2ndQuarter    NullFloat64 `json:"ForecastDividendPerShare2ndQuarter"`
+	ForecastDividendPerShare3rdQuarter    NullFloat64 `json:"ForecastDividendPerShare3rdQuarter"`
+	ForecastDividendPerShareFiscalYearEnd NullFloat64 `json:"ForecastDividendPerShareFiscalYearEnd"`
+	ForecastDividendPerShareAnnual        NullFloat64 `json:"ForecastDividendPerShareAnnual"`
+	ForecastTotalDividendPaidAnnual       NullFloat64 `json:"ForecastTotalDividendPaidAnnual"`
+	ForecastPayoutRatioAnnual             NullFloat64 `json:"ForecastPayoutRatioAnnual"`
+
+	NextYearForecastDividendPerShare1stQuarter    NullFloat64 `json:"NextYearForecastDividendPerShare1stQuarter"`
+	NextYearForecastDividendPerShare2ndQuarter    NullFloat64 `json:"NextYearForecastDividendPerShare2ndQuarter"`
+	NextYearForecastDividendPerShare3rdQuarter    NullFloat64 `json:"NextYearForecastDividendPerShare3rdQuarter"`
+	NextYearForecastDividendPerShareFiscalYearEnd NullFloat64 `json:"NextYearForecastDividendPerShareFiscalYearEnd"`
+	NextYearForecastDividendPerShareAnnual        NullFloat64 `json:"NextYearForecastDividendPerShareAnnual"`
+	NextYearForecastPayoutRatioAnnual             NullFloat64 `json:"NextYearForecastPayoutRatioAnnual"`
+
+	ForecastNetSales2ndQuarter         NullFloat64 `json:"ForecastNetSales2ndQuarter"`
+	ForecastOperatingProfit2ndQuarter  NullFloat64 `json:"ForecastOperatingProfit2ndQuarter"`
+	ForecastOrdinaryProfit2ndQuarter   NullFloat64 `json:"ForecastOrdinaryProfit2ndQuarter"`
+	ForecastProfit2ndQuarter           NullFloat64 `json:"ForecastProfit2ndQuarter"`
+	ForecastEarningsPerShare2ndQuarter NullFloat64 `json:"ForecastEarningsPerShare2ndQuarter"`
+	ForecastNetSales                   NullFloat64 `json:"ForecastNetSales"`
+	ForecastOperatingProfit            NullFloat64 `json:"ForecastOperatingProfit"`
+	ForecastOrdinaryProfit             NullFloat64 `json:"ForecastOrdinaryProfit"`
+	ForecastProfit                     NullFloat64 `json:"ForecastProfit"`
+	ForecastEarningsPerShare           NullFloat64 `json:"ForecastEarningsPerShare"`
+	NextYearForecastNetSales           NullFloat64 `json:"NextYearForecastNetSales"`
+	NextYearForecastOperatingProfit    NullFloat64 `json:"NextYearForecastOperatingProfit"`
+	NextYearForecastOrdinaryProfit     NullFloat64 `json:"NextYearForecastOrdinaryProfit"`
+	NextYearForecastProfit             NullFloat64 `json:"NextYearForecastProfit"`
+	NextYearForecastEarningsPerShare   NullFloat64 `json:"NextYearForecastEarningsPerShare"`
+
+	NumberOfIssuedAndOutstandingSharesAtTheEndOfFiscalYearIncludingTreasuryStock NullFloat64 `json:"NumberOfIssuedAndOutstandingSharesAtTheEndOfFiscalYearIncludingTreasuryStock"`
+	NumberOfTreasuryStockAtTheEndOfFiscalYear                                    NullFloat64 `json:"NumberOfTreasuryStockAtTheEndOfFiscalYear"`
+	AverageNumberOfShares                                                        NullFloat64 `json:"AverageNumberOfShares"`
+}
+
+var statementsEndpoint = endpoint[Statement]{"/fins/statements", "statements"}
+
+// StatementsRequest selects the disclosures to fetch, either all
+// disclosures of Code or all disclosures made on Date.
+type StatementsRequest struct {
+	Code string
+	Date time.Time
+}
+
+// Validate reports invalid parameters.
+func (r StatementsRequest) Validate() error {
+	return validateCodeOrDate(r.Code, r.Date, time.Time{}, time.Time{})
+}
+
+func (r StatementsRequest) query() url.Values {
+	return codeOrDateQuery(r.Code, r.Date, time.Time{}, time.Time{})
+}
+
+// Statements returns the financial statements selected by req.
+func (c *Client) Statements(ctx context.Context, req StatementsRequest) ([]Statement, error) {
+	return statementsEndpoint.list(ctx, c, req)
+}
+
+// Announcement is a scheduled earnings release. J-Quants publishes the
+// schedule of the next business day.
+type Announcement struct {
+	Date          Date   `json:"Date"`
+	Code          string `json:"Code"`
+	CompanyName   string `json:"CompanyName"`
+	FiscalYear    string `json:"FiscalYear"`
+	SectorName    string `json:"SectorName"`
+	FiscalQuarter string `json:"FiscalQuarter"`
+	Section       string `json:"Section"`
+}
+
+var announcementEndpoint = endpoint[Announcement]{"/fins/announcement", "announcement"}
+
+// Announcements returns the upcoming earnings releases.
+func (c *Client) Announcements(ctx context.Context) ([]Announcement, error) {
+	return announcementEndpoint.list(ctx, c, noParams{})
+}
+
+// Status codes of a dividend announcement.
+const (
+	DividendStatusNew     = "1"
+	DividendStatusRevised = "2"
+	DividendStatusDeleted = "3"
+)
+
+// Commemorative/special codes of a dividend.
+const (
+	DividendNormal                  = "0"
+	DividendCommemorative           = "1"
+	DividendSpecial                 = "2"
+	DividendCommemorativeAndSpecial = "3"
+)
+
+// Dividend is an announced dividend. Later announcements with the same
+// ReferenceNumber revise or delete it. Amounts are per share, in yen; they
+// are null when undetermined.
+type Dividend struct {
+	AnnouncementDate          Date        `json:"AnnouncementDate"`
+	AnnouncementTime          string      `json:"AnnouncementTime"`
+	Code                      string      `json:"Code"`
+	ReferenceNumber           string      `json:"ReferenceNumber"`
+	StatusCode                string      `json:"StatusCode"`
+	BoardMeetingDate          Date        `json:"BoardMeetingDate"`
+	InterimFinalCode          string      `json:"InterimFinalCode"`
+	ForecastResultCode        string      `json:"ForecastResultCode"`
+	InterimFinalTerm          string      `json:"InterimFinalTerm"`
+	GrossDividendRate         NullFloat64 `json:"GrossDividendRate"`
+	RecordDate                Date        `json:"RecordDate"`
+	ExDate                    Date        `json:"ExDate"`
+	ActualRecordDate          Date        `json:"ActualRecordDate"`
+	PayableDate               Date        `json:"PayableDate"`
+	CAReferenceNumber         string      `json:"CAReferenceNumber"`
+	DistributionAmount        NullFloat64 `json:"DistributionAmount"`
+	RetainedEarnings          NullFloat64 `json:"RetainedEarnings"`
+	DeemedDividend            NullFloat64 `json:"DeemedDividend"`
+	DeemedCapitalGains        NullFloat64 `json:"DeemedCapitalGains"`
+	NetAssetDecreaseRatio     NullFloat64 `json:"NetAssetDecreaseRatio"`
+	CommemorativeSpecialCode  string      `json:"CommemorativeSpecialCode"`
+	CommemorativeDividendRate NullFloat64 `json:"CommemorativeDividendRate"`
+	SpecialDividendRate       NullFloat64 `json:"SpecialDividendRate"`
+}
+
+// IsRevision reports whether d revises an earlier announcement.
+func (d Dividend) IsRevision() bool {
+	return d.StatusCode == DividendStatusRevised
+}
+
+// IsDeleted reports whether d withdraws an earlier announcement.
+func (d Dividend) IsDeleted() bool {
+	return d.StatusCode == DividendStatusDeleted
+}
+
+// IsCommemorative reports whether d includes a commemorative dividend.
+func (d Dividend) IsCommemorative() bool {
+	return d.CommemorativeSpecialCode == DividendCommemorative || d.CommemorativeSpecialCode == DividendCommemorativeAndSpecial
+}
+
+// IsSpecial reports whether d includes a special dividend.
+func (d Dividend) IsSpecial() bool {
+	return d.CommemorativeSpecialCode == DividendSpecial || d.CommemorativeSpecialCode == DividendCommemorativeAndSpecial
+}
+
+var dividendEndpoint = endpoint[Dividend]{"/fins/dividend", "dividend"}
+
+// DividendRequest selects dividend announcements. Either Code or Date must
+// be set: Date alone returns all announcements made on that day, Code alone
+// all announcements of the issue, optionally narrowed with From and/or To.
+type DividendRequest struct {
+	Code string
+	Date time.Time
+	From time.Time
+	To   time.Time
+}
+
+// Validate reports invalid parameter combinations.
+func (r DividendRequest) Validate() error {
+	return validateCodeOrDate(r.Code, r.Date, r.From, r.To)
+}
+
+func (r DividendRequest) query() url.Values {
+	return codeOrDateQuery(r.Code, r.Date, r.From, r.To)
+}
+
+// Dividends returns the dividend announcements selected by req.
+func (c *Client) Dividends(ctx context.Context, req DividendRequest) ([]Dividend, error) {
+	return dividendEndpoint.list(ctx, c, req)
+}
+
+// Labels tried, in order, by the accessors of StatementDetails. Companies
+// reporting under JGAAP and IFRS use different labels for the same item.
+var (
+	totalAssetsLabels = []string{
+		"Total assets",
+		"Assets",
+		"Assets (IFRS)",
+		"Total assets (IFRS)",
+	}
+	equityLabels = []string{
+		"Net assets",
+		"Total net assets",
+		"Equity (IFRS)",
+		"Total equity (IFRS)",
+	}
+	operatingCashFlowLabels = []string{
+		"Net cash provided by (used in) operating activities",
+		"Net cash provided by (used in) operating activities (IFRS)",
+		"Cash flows from (used in) operating activities (IFRS)",
+	}
+)
+
+// StatementDetails holds the balance sheet, income statement and cash flow
+// items of a disclosure. FinancialStatement maps the XBRL labels, such as
+// "Goodwill (IFRS)", to their values as sent by J-Quants.
+type StatementDetails struct {
+	DisclosedDate      Date              `json:"DisclosedDate"`
+	DisclosedTime      string            `json:"DisclosedTime"`
+	LocalCode          string            `json:"LocalCode"`
+	DisclosureNumber   string            `json:"DisclosureNumber"`
+	TypeOfDocument     string            `json:"TypeOfDocument"`
+	FinancialStatement map[string]string `json:"FinancialStatement"`
+}
+
+// Value parses the item with the given label. Missing and empty items are
+// null.
+func (d StatementDetails) Value(label string) (NullFloat64, error) {
+	return ParseNullFloat64(d.FinancialStatement[label])
+}
+
+// Lookup returns the first of labels with a numeric value, or null.
+func (d StatementDetails) Lookup(labels ...string) NullFloat64 {
+	for _, label := range labels {
+		if v, err := d.Value(label); err == nil && v.Valid {
+			return v
+		}
+	}
+	return NullFloat64{}
+}
+
+// TotalAssets returns the total assets.
+func (d StatementDetails) TotalAssets() NullFloat64 {
+	return d.Lookup(totalAssetsLabels...)
+}
+
+// Equity returns the net assets, or the total equity under IFRS.
+func (d StatementDetails) Equity() NullFloat64 {
+	return d.Lookup(equityLabels...)
+}
+
+// OperatingCashFlow returns the net cash from operating activities.
+func (d StatementDetails) OperatingCashFlow() NullFloat64 {
+	return d.Lookup(operatingCashFlowLabels...)
+}
+
+var statementDetailsEndpoint = endpoint[StatementDetails]{"/fins/fs_details", "fs_details"}
+
+// StatementDetails returns the financial statement details selected by
+// req, i.e. all disclosures of req.Code or all disclosures made on req.Date.
+func (c *Client) StatementDetails(ctx context.Context, req StatementsRequest) ([]StatementDetails, error) {
+	return statementDetailsEndpoint.list(ctx, c, req)
+}
@@ -0,0 +1,123 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStatements(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fins/statements" || r.URL.Query().Get("code") != "86970" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"statements":[{"DisclosedDate":"2023-01-30","DisclosedTime":"12:00:00","LocalCode":"86970","DisclosureNumber":"20230127594871","TypeOfDocument":"3QFinancialStatements_Consolidated_IFRS","TypeOfCurrentPeriod":"3Q","CurrentPeriodStartDate":"2022-04-01","CurrentPeriodEndDate":"2022-12-31","NetSales":"100529000000","OperatingProfit":"51765000000","OrdinaryProfit":"","Profit":"35175000000","EarningsPerShare":"66.76","EquityToAssetRatio":"0.006","ForecastDividendPerShareAnnual":"-"}]}`))
+	})
+
+	statements, err := client.Statements(context.Background(), StatementsRequest{Code: "86970"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("expected one statement, got %d", len(statements))
+	}
+	s := statements[0]
+	if s.DisclosedDate != NewDate(2023, time.January, 30) || s.CurrentPeriodEndDate != NewDate(2022, time.December, 31) {
+		t.Errorf("unexpected dates %+v", s)
+	}
+	if s.NetSales != NewNullFloat64(100529000000) || s.EarningsPerShare != NewNullFloat64(66.76) {
+		t.Errorf("unexpected figures %+v", s)
+	}
+	if s.OrdinaryProfit.Valid || s.ForecastDividendPerShareAnnual.Valid || s.TotalAssets.Valid {
+		t.Errorf("empty figures should be null: %+v", s)
+	}
+}
+
+func TestStatementsRequestValidate(t *testing.T) {
+	if err := (StatementsRequest{}).Validate(); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+	if err := (StatementsRequest{Date: time.Now()}).Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestAnnouncements(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fins/announcement" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("pagination_key") == "" {
+			w.Write([]byte(`{"announcement":[{"Date":"2022-02-14","Code":"43760","CompanyName":"くふうカンパニー","FiscalYear":"9月30日","SectorName":"情報・通信業","FiscalQuarter":"第1四半期","Section":"マザーズ"}],"pagination_key":"next"}`))
+			return
+		}
+		w.Write([]byte(`{"announcement":[{"Date":"2022-02-14","Code":"86970"}]}`))
+	})
+
+	announcements, err := client.Announcements(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(announcements) != 2 || announcements[0].FiscalQuarter != "第1四半期" || announcements[1].Code != "86970" {
+		t.Errorf("unexpected announcements %+v", announcements)
+	}
+	if announcements[0].Date != NewDate(2022, time.February, 14) {
+		t.Errorf("unexpected date %v", announcements[0].Date)
+	}
+}
+
+func TestDividends(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fins/dividend" || r.URL.Query().Get("code") != "15550" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"dividend":[{"AnnouncementDate":"2014-02-24","AnnouncementTime":"09:21","Code":"15550","ReferenceNumber":"201402241B00002","StatusCode":"1","BoardMeetingDate":"2014-02-24","InterimFinalCode":"2","ForecastResultCode":"2","InterimFinalTerm":"2014-03","GrossDividendRate":"-","RecordDate":"2014-03-10","ExDate":"2014-03-06","ActualRecordDate":"2014-03-10","PayableDate":"-","CAReferenceNumber":"201402241B00002","DistributionAmount":"","RetainedEarnings":"","DeemedDividend":"","DeemedCapitalGains":"","NetAssetDecreaseRatio":"","CommemorativeSpecialCode":"2","CommemorativeDividendRate":"","SpecialDividendRate":"12.5"}]}`))
+	})
+
+	dividends, err := client.Dividends(context.Background(), DividendRequest{Code: "15550"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dividends) != 1 {
+		t.Fatalf("expected one dividend, got %d", len(dividends))
+	}
+	d := dividends[0]
+	if d.ExDate != NewDate(2014, time.March, 6) || !d.PayableDate.IsZero() || d.GrossDividendRate.Valid {
+		t.Errorf("unexpected dividend %+v", d)
+	}
+	if !d.IsSpecial() || d.IsCommemorative() || d.IsRevision() || d.SpecialDividendRate != NewNullFloat64(12.5) {
+		t.Errorf("unexpected special dividend %+v", d)
+	}
+}
+
+func TestStatementDetails(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fins/fs_details" || r.URL.Query().Get("date") != "20230130" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"fs_details":[{"DisclosedDate":"2023-01-30","DisclosedTime":"12:00:00","LocalCode":"86970","DisclosureNumber":"20230127594871","TypeOfDocument":"3QFinancialStatements_Consolidated_IFRS","FinancialStatement":{"Goodwill (IFRS)":"67374000000","Assets (IFRS)":"79205861000000","Equity (IFRS)":"","Total equity (IFRS)":"320426000000","Basic earnings (loss) per share (IFRS)":"66.76","Document type, DEI":"四半期第３号参考様式　[IFRS]（連結）"}}]}`))
+	})
+
+	details, err := client.StatementDetails(context.Background(), StatementsRequest{Date: time.Date(2023, 1, 30, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details) != 1 {
+		t.Fatalf("expected one disclosure, got %d", len(details))
+	}
+	d := details[0]
+	if d.TotalAssets() != NewNullFloat64(79205861000000) || d.Equity() != NewNullFloat64(320426000000) {
+		t.Errorf("unexpected balance sheet items %v, %v", d.TotalAssets(), d.Equity())
+	}
+	if d.OperatingCashFlow().Valid {
+		t.Errorf("missing items should be null")
+	}
+	if v, err := d.Value("Goodwill (IFRS)"); err != nil || v != NewNullFloat64(67374000000) {
+		t.Errorf("unexpected goodwill %v, %v", v, err)
+	}
+	if _, err := d.Value("Document type, DEI"); err == nil {
+		t.Errorf("text items should not parse as numbers")
+	}
+}
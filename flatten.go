@@ -0,0 +1,95 @@
+package jquants_api_go
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Some endpoints return groups of related values as flat keys sharing a
+// prefix, e.g. ForeignersSales and ForeignersPurchases. Struct fields tagged
+// `flatten:"Foreigners"` collect such a group into a nested struct whose
+// fields are named after the rest of the key. Tagged fields of embedded
+// structs are handled as well.
+
+// unflatten fills the flatten-tagged fields of the struct v points to from
+// the JSON object data.
+func unflatten(data []byte, v interface{}) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unflattenStruct(raw, reflect.ValueOf(v).Elem())
+}
+
+func unflattenStruct(raw map[string]json.RawMessage, outer reflect.Value) error {
+	for i := 0; i < outer.NumField(); i++ {
+		field := outer.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := unflattenStruct(raw, outer.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		prefix, ok := field.Tag.Lookup("flatten")
+		if !ok {
+			continue
+		}
+		group := outer.Field(i)
+		for j := 0; j < group.NumField(); j++ {
+			value, ok := raw[prefix+jsonName(group.Type().Field(j))]
+			if !ok {
+				continue
+			}
+			if err := json.Unmarshal(value, group.Field(j).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flatten adds the flatten-tagged fields of the struct v to the JSON object
+// data.
+func flatten(data []byte, v interface{}) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if err := flattenStruct(raw, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(raw)
+}
+
+func flattenStruct(raw map[string]json.RawMessage, outer reflect.Value) error {
+	for i := 0; i < outer.NumField(); i++ {
+		field := outer.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := flattenStruct(raw, outer.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		prefix, ok := field.Tag.Lookup("flatten")
+		if !ok {
+			continue
+		}
+		group := outer.Field(i)
+		for j := 0; j < group.NumField(); j++ {
+			value, err := json.Marshal(group.Field(j).Interface())
+			if err != nil {
+				return err
+			}
+			raw[prefix+jsonName(group.Type().Field(j))] = value
+		}
+	}
+	return nil
+}
+
+func jsonName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
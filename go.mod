@@ -1,5 +1,5 @@
 module github.com/hellonico/jquants-api-go
 
-go 1.17
+go 1.18
 
 require olympos.io/encoding/edn v0.0.0-20201019073823-d3554ca0b0a3
@@ -1,10 +1,9 @@
 package jquants_api_go
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"net/url"
 	"olympos.io/encoding/edn"
 	"os"
 	"time"
@@ -13,184 +12,303 @@ import (
 const BASE_URL = "https://api.jpx-jquants.com/v1"
 const REFRESH_TOKEN_FILE = "refresh_token.edn"
 const ID_TOKEN_FILE = "id_token.edn"
+const LOGIN_FILE = "login.edn"
 
 type Login struct {
 	UserName string `edn:"mailaddress" json:"mailaddress"`
 	Password string `edn:"password" json:"password"`
 }
 type RefreshToken struct {
-	RefreshToken string `edn:"refreshToken" json:"refreshToken"`
+	RefreshToken string    `edn:"refreshToken" json:"refreshToken"`
+	IssuedAt     time.Time `edn:"issuedAt" json:"-"`
 }
 type IdToken struct {
-	IdToken string `edn:"idToken" json:"idToken"`
+	IdToken  string    `edn:"idToken" json:"idToken"`
+	IssuedAt time.Time `edn:"issuedAt" json:"-"`
 }
 
-type JSONTime int64
-
-/*
-https://kenzo0107.github.io/2020/05/19/2020-05-20-go-json-time/
-*/
-
-// String converts the unix timestamp into a string
-func (t JSONTime) String() string {
-	tm := t.Time()
-	return fmt.Sprintf("\"%s\"", tm.Format("2006-01-02"))
-}
-
-// Time returns a `time.Time` representation of this value.
-func (t JSONTime) Time() time.Time {
-	return time.Unix(int64(t), 0)
+// Quote is the daily price of an issue. Prices and volumes are null on days
+// the issue did not trade, e.g. because trading was halted.
+type Quote struct {
+	Code             string      `json:"Code"`
+	Close            NullFloat64 `json:"Close"`
+	Date             Date        `json:"Date"`
+	AdjustmentHigh   NullFloat64 `json:"AdjustmentHigh"`
+	Volume           NullFloat64 `json:"Volume"`
+	TurnoverValue    NullFloat64 `json:"TurnoverValue"`
+	AdjustmentClose  NullFloat64 `json:"AdjustmentClose"`
+	AdjustmentLow    NullFloat64 `json:"AdjustmentLow"`
+	Low              NullFloat64 `json:"Low"`
+	High             NullFloat64 `json:"High"`
+	Open             NullFloat64 `json:"Open"`
+	AdjustmentOpen   NullFloat64 `json:"AdjustmentOpen"`
+	AdjustmentFactor float64     `json:"AdjustmentFactor"`
+	AdjustmentVolume NullFloat64 `json:"AdjustmentVolume"`
 }
 
-// UnmarshalJSON will unmarshal both string and int JSON values
-func (t *JSONTime) UnmarshalJSON(buf []byte) error {
-	s := bytes.Trim(buf, `"`)
-	aa, _ := time.Parse("20060102", string(s))
-	*t = JSONTime(aa.Unix())
-	return nil
+// HasPrice reports whether the issue traded that day, i.e. whether open,
+// high, low and close are all present.
+func (q Quote) HasPrice() bool {
+	return q.Open.Valid && q.High.Valid && q.Low.Valid && q.Close.Valid
 }
 
-type Quote struct {
-	Code             string   `json:"Code"`
-	Close            float64  `json:"Close"`
-	Date             JSONTime `json:"Date"`
-	AdjustmentHigh   float64  `json:"AdjustmentHigh"`
-	Volume           float64  `json:"Volume"`
-	TurnoverValue    float64  `json:"TurnoverValue"`
-	AdjustmentClose  float64  `json:"AdjustmentClose"`
-	AdjustmentLow    float64  `json:"AdjustmentLow"`
-	Low              float64  `json:"Low"`
-	High             float64  `json:"High"`
-	Open             float64  `json:"Open"`
-	AdjustmentOpen   float64  `json:"AdjustmentOpen"`
-	AdjustmentFactor float64  `json:"AdjustmentFactor"`
-	AdjustmentVolume float64  `json:"AdjustmentVolume"`
-}
 type DailyQuotes struct {
-	DailyQuotes []Quote `json:"daily_quotes"`
+	DailyQuotes   []Quote `json:"daily_quotes"`
+	PaginationKey string  `json:"pagination_key,omitempty"`
 }
 
+// Check panics if e is not nil.
+//
+// Deprecated: the package no longer panics on errors; handle the errors
+// returned by its functions instead.
 func Check(e error) {
 	if e != nil {
 		panic(e)
 	}
 }
 
-func sendRequest(url string, idToken string) *http.Response {
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	Check(err)
-
-	req.Header = http.Header{
-		"Authorization": {"Bearer " + idToken},
-	}
-
-	client := http.Client{}
-	res, _ := client.Do(req)
-	return res
-}
-
 /**
 PRIVATE METHODS TO READ / WRITE CONFIG FILES
 */
-func getConfigDir() string {
-	homeDir, _ := os.UserHomeDir()
+func getConfigDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
 	configDir := homeDir + "/.config/jquants/"
-	os.MkdirAll(configDir, os.ModePerm)
-	return configDir
+	return configDir, os.MkdirAll(configDir, os.ModePerm)
+}
+func readConfigFile(file string) ([]byte, error) {
+	path, err := getConfigFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+func writeConfigFile(file string, content []byte) error {
+	path, err := getConfigFile(file)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0664)
 }
-func readConfigFile(file string) []byte {
-	s, _ := os.ReadFile(getConfigDir() + file)
-	return s
+func getConfigFile(file string) (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", configDir, file), nil
 }
-func writeConfigFile(file string, content []byte) {
-	os.WriteFile(getConfigFile(file), content, 0664)
+func readConfigEDN(file string, v interface{}) error {
+	s, err := readConfigFile(file)
+	if err != nil {
+		return err
+	}
+	return edn.Unmarshal(s, v)
 }
-func getConfigFile(file string) string {
-	return fmt.Sprintf("%s/%s", getConfigDir(), file)
+func writeConfigEDN(file string, v interface{}) error {
+	encoded, err := edn.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(file, encoded)
 }
 
-func GetUser() Login {
-	s, _ := os.ReadFile(getConfigFile("login.edn"))
+func GetUser() (Login, error) {
 	var user Login
-	edn.Unmarshal(s, &user)
-	return user
+	err := readConfigEDN(LOGIN_FILE, &user)
+	return user, err
 }
-func ReadRefreshToken() RefreshToken {
+func ReadRefreshToken() (RefreshToken, error) {
 	var refreshToken RefreshToken
-	s := readConfigFile(REFRESH_TOKEN_FILE)
-	edn.Unmarshal(s, &refreshToken)
-	return refreshToken
+	err := readConfigEDN(REFRESH_TOKEN_FILE, &refreshToken)
+	return refreshToken, err
 }
 
-func ReadIdToken() IdToken {
+func ReadIdToken() (IdToken, error) {
 	var idToken IdToken
-	s := readConfigFile(ID_TOKEN_FILE)
-	edn.Unmarshal(s, &idToken)
-	return idToken
+	err := readConfigEDN(ID_TOKEN_FILE, &idToken)
+	return idToken, err
 }
 
-func PrepareLogin(username string, password string) {
+func PrepareLogin(username string, password string) error {
 	var user = Login{username, password}
-	encoded, _ := edn.Marshal(&user)
-	writeConfigFile("login.edn", encoded)
+	return writeConfigEDN(LOGIN_FILE, &user)
 }
 
+// defaultClient backs the package level functions.
+var defaultClient = NewClient()
+
 func GetRefreshToken() (RefreshToken, error) {
-	var user = GetUser()
-	url := fmt.Sprintf("%s/token/auth_user", BASE_URL)
-	// fmt.Printf("%s", url)
+	return GetRefreshTokenContext(context.Background())
+}
+
+// GetRefreshTokenContext is like GetRefreshToken but honours ctx.
+func GetRefreshTokenContext(ctx context.Context) (RefreshToken, error) {
+	user, err := GetUser()
+	if err != nil {
+		return RefreshToken{}, err
+	}
+	rt, err := defaultClient.Authenticate(ctx, user)
+	if err != nil {
+		return rt, err
+	}
+	return rt, ConfigTokenStore().SaveRefreshToken(rt)
+}
+
+func GetIdToken() (IdToken, error) {
+	return GetIdTokenContext(context.Background())
+}
 
-	data, err := json.Marshal(user)
-	// https://golang.cafe/blog/golang-convert-byte-slice-to-io-reader.html
+// GetIdTokenContext is like GetIdToken but honours ctx.
+func GetIdTokenContext(ctx context.Context) (IdToken, error) {
+	refreshToken, err := ReadRefreshToken()
+	if err != nil {
+		return IdToken{}, err
+	}
+	it, err := defaultClient.RefreshIdToken(ctx, refreshToken)
+	if err != nil {
+		return it, err
+	}
+	return it, ConfigTokenStore().SaveIdToken(it)
+}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+func Daily(code string, date string, from string, to string) (DailyQuotes, error) {
+	return DailyContext(context.Background(), code, date, from, to)
+}
 
-	// fmt.Println(req)
-	client := http.Client{}
-	res, err := client.Do(req)
+// DailyContext is like Daily but honours ctx.
+func DailyContext(ctx context.Context, code string, date string, from string, to string) (DailyQuotes, error) {
+	req, err := parseDailyQuotesRequest(code, date, from, to)
+	if err != nil {
+		return DailyQuotes{}, err
+	}
+	quotes, err := defaultClient.Daily(ctx, req)
+	return DailyQuotes{DailyQuotes: quotes}, err
+}
 
-	var rt RefreshToken
-	json.NewDecoder(res.Body).Decode(&rt)
+// parseDailyQuotesRequest converts the string arguments of Daily. Dates are
+// given as YYYYMMDD or YYYY-MM-DD; a range takes precedence over date.
+func parseDailyQuotesRequest(code string, date string, from string, to string) (DailyQuotesRequest, error) {
+	req := DailyQuotesRequest{Code: code}
+	var err error
+	if from != "" || to != "" {
+		if req.From, err = parseRequestDate("from", from); err != nil {
+			return req, err
+		}
+		req.To, err = parseRequestDate("to", to)
+		return req, err
+	}
+	req.Date, err = parseRequestDate("date", date)
+	return req, err
+}
 
-	encoded, err := edn.Marshal(&rt)
-	writeConfigFile(REFRESH_TOKEN_FILE, encoded)
+func parseRequestDate(name string, s string) (time.Time, error) {
+	d, err := ParseDate(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s: %v", ErrInvalidRequest, name, err)
+	}
+	if d.IsZero() {
+		return time.Time{}, nil
+	}
+	return d.Time(), nil
+}
 
-	return rt, err
+// DailyQuotesRequest selects the daily quotes to fetch. Either Code or Date
+// must be set: Date alone returns all issues on that day, Code alone its
+// whole history, optionally narrowed with From and/or To.
+type DailyQuotesRequest struct {
+	Code string
+	Date time.Time
+	From time.Time
+	To   time.Time
 }
 
-func GetIdToken() (IdToken, error) {
-	var token = ReadRefreshToken()
+// Validate reports invalid parameter combinations.
+func (r DailyQuotesRequest) Validate() error {
+	return validateCodeOrDate(r.Code, r.Date, r.From, r.To)
+}
 
-	url := fmt.Sprintf("%s/token/auth_refresh?refreshtoken=%s", BASE_URL, token.RefreshToken)
+func (r DailyQuotesRequest) query() url.Values {
+	return codeOrDateQuery(r.Code, r.Date, r.From, r.To)
+}
+
+var dailyQuotesEndpoint = endpoint[Quote]{"/prices/daily_quotes", "daily_quotes"}
+
+// Daily returns the daily quotes selected by req. All pages of the result
+// are fetched.
+func (c *Client) Daily(ctx context.Context, req DailyQuotesRequest) ([]Quote, error) {
+	return dailyQuotesEndpoint.list(ctx, c, req)
+}
 
-	req, err := http.NewRequest(http.MethodPost, url, nil)
-	client := http.Client{}
-	res, err := client.Do(req)
+// DailyPages is like Daily but returns the result page by page, so that
+// large results need not be held in memory at once.
+func (c *Client) DailyPages(ctx context.Context, req DailyQuotesRequest) *DailyQuotesPages {
+	return &DailyQuotesPages{dailyQuotesEndpoint.pages(ctx, c, req)}
+}
 
-	var rt IdToken
-	json.NewDecoder(res.Body).Decode(&rt)
+// DailyQuotesPages iterates over the pages of a daily quotes result:
+//
+//	pages := client.DailyPages(ctx, jquants.DailyQuotesRequest{Date: date})
+//	for pages.Next() {
+//		for _, quote := range pages.Quotes() {
+//			...
+//		}
+//	}
+//	if err := pages.Err(); err != nil {
+//		...
+//	}
+type DailyQuotesPages struct {
+	*Pager[Quote]
+}
 
-	encoded, err := edn.Marshal(&rt)
-	writeConfigFile(ID_TOKEN_FILE, encoded)
+// Quotes returns the quotes of the current page.
+func (p *DailyQuotesPages) Quotes() []Quote {
+	return p.Items()
+}
 
-	return rt, err
+// MorningQuote is the price of an issue in the morning session. Like in
+// Quote, prices and volumes are null when the issue did not trade.
+type MorningQuote struct {
+	Date                 Date        `json:"Date"`
+	Code                 string      `json:"Code"`
+	MorningOpen          NullFloat64 `json:"MorningOpen"`
+	MorningHigh          NullFloat64 `json:"MorningHigh"`
+	MorningLow           NullFloat64 `json:"MorningLow"`
+	MorningClose         NullFloat64 `json:"MorningClose"`
+	MorningVolume        NullFloat64 `json:"MorningVolume"`
+	MorningTurnoverValue NullFloat64 `json:"MorningTurnoverValue"`
 }
 
-func Daily(code string, date string, from string, to string) DailyQuotes {
-	idtoken := ReadIdToken()
+// HasPrice reports whether the issue traded in the morning session.
+func (q MorningQuote) HasPrice() bool {
+	return q.MorningOpen.Valid && q.MorningHigh.Valid && q.MorningLow.Valid && q.MorningClose.Valid
+}
 
-	baseUrl := fmt.Sprintf("%s/prices/daily_quotes?code=%s", BASE_URL, code)
-	var url string
-	if from != "" && to != "" {
-		url = fmt.Sprintf("%s&from=%s&to=%s", baseUrl, from, to)
-	} else {
-		url = fmt.Sprintf("%s&date=%s", baseUrl, date)
+var morningQuotesEndpoint = endpoint[MorningQuote]{"/prices/prices_am", "prices_am"}
+
+// MorningQuotesRequest selects the morning session prices of the latest
+// trading day, for Code or for all issues when Code is empty.
+type MorningQuotesRequest struct {
+	Code string
+}
+
+// Validate reports invalid parameters.
+func (r MorningQuotesRequest) Validate() error {
+	if r.Code != "" {
+		return validateCode(r.Code)
 	}
-	res := sendRequest(url, idtoken.IdToken)
+	return nil
+}
+
+func (r MorningQuotesRequest) query() url.Values {
+	query := url.Values{}
+	setString(query, "code", r.Code)
+	return query
+}
 
-	var quotes DailyQuotes
-	err_ := json.NewDecoder(res.Body).Decode(&quotes)
-	Check(err_)
-	return quotes
+// MorningQuotes returns the morning session prices selected by req. All
+// pages of the result are fetched.
+func (c *Client) MorningQuotes(ctx context.Context, req MorningQuotesRequest) ([]MorningQuote, error) {
+	return morningQuotesEndpoint.list(ctx, c, req)
 }
@@ -1,17 +1,32 @@
 package jquants_api_go
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestPrepareLogin(t *testing.T) {
-	PrepareLogin(os.Getenv("USERNAME"), os.Getenv("PASSWORD"))
-	fmt.Printf("Loaded User: %s\n", GetUser().UserName)
+	if err := PrepareLogin(os.Getenv("USERNAME"), os.Getenv("PASSWORD")); err != nil {
+		t.Fatal(err)
+	}
+	user, err := GetUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("Loaded User: %s\n", user.UserName)
 }
 func TestRefreshToken(t *testing.T) {
-	token, _ := GetRefreshToken()
+	token, err := GetRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
 	fmt.Printf("%s\n", token)
 	if token.RefreshToken == "" {
 		t.Errorf("Could not retrieve refresh token")
@@ -19,7 +34,10 @@ func TestRefreshToken(t *testing.T) {
 }
 
 func TestIdToken(t *testing.T) {
-	token, _ := GetIdToken()
+	token, err := GetIdToken()
+	if err != nil {
+		t.Fatal(err)
+	}
 	fmt.Printf("%s\n", token)
 	if token.IdToken == "" {
 		t.Errorf("Could not retrieve id token")
@@ -27,11 +45,144 @@ func TestIdToken(t *testing.T) {
 }
 
 func TestDaily(t *testing.T) {
-	var quotes = Daily("86970", "", "20220929", "20221003")
+	quotes, err := Daily("86970", "", "20220929", "20221003")
+	if err != nil {
+		t.Fatal(err)
+	}
 	for _, quote := range quotes.DailyQuotes {
-		fmt.Printf("%s,%f\n", quote.Date, quote.Close)
+		fmt.Printf("%s,%s\n", quote.Date, quote.Close)
 	}
 	if quotes.DailyQuotes == nil {
 		t.Errorf("Could not retrieve quotes")
 	}
 }
+
+func TestDailyPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pagination_key") {
+		case "":
+			w.Write([]byte(`{"daily_quotes":[{"Code":"13010"},{"Code":"13050"}],"pagination_key":"page 2"}`))
+		case "page 2":
+			w.Write([]byte(`{"daily_quotes":[{"Code":"13060"}],"pagination_key":"page3"}`))
+		case "page3":
+			w.Write([]byte(`{"daily_quotes":[{"Code":"13080"}]}`))
+		default:
+			t.Errorf("unexpected pagination key %q", r.URL.Query().Get("pagination_key"))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource("test-token")))
+
+	quotes, err := client.Daily(context.Background(), DailyQuotesRequest{Date: time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 4 || quotes[3].Code != "13080" {
+		t.Errorf("expected all pages to be fetched, got %v", quotes)
+	}
+
+	pages := client.DailyPages(context.Background(), DailyQuotesRequest{Date: time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC)})
+	var sizes []int
+	for pages.Next() {
+		sizes = append(sizes, len(pages.Quotes()))
+	}
+	if err := pages.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sizes) != "[2 1 1]" {
+		t.Errorf("unexpected page sizes %v", sizes)
+	}
+}
+
+var testDailyRequest = DailyQuotesRequest{Code: "86970", Date: time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC)}
+
+func TestDailyQuotesRequestValidate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2022, 9, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		req   DailyQuotesRequest
+		valid bool
+	}{
+		{DailyQuotesRequest{Code: "86970", Date: day(30)}, true},
+		{DailyQuotesRequest{Date: day(30)}, true},
+		{DailyQuotesRequest{Code: "8697"}, true},
+		{DailyQuotesRequest{Code: "130A", From: day(1)}, true},
+		{DailyQuotesRequest{Code: "8697", From: day(1), To: day(30)}, true},
+		{DailyQuotesRequest{}, false},
+		{DailyQuotesRequest{From: day(1), To: day(30)}, false},
+		{DailyQuotesRequest{Code: "869", Date: day(30)}, false},
+		{DailyQuotesRequest{Code: "8697-", Date: day(30)}, false},
+		{DailyQuotesRequest{Code: "8697", Date: day(30), From: day(1)}, false},
+		{DailyQuotesRequest{Code: "8697", From: day(30), To: day(1)}, false},
+	}
+	for _, test := range tests {
+		err := test.req.Validate()
+		if test.valid && err != nil {
+			t.Errorf("%+v: unexpected error %v", test.req, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%+v: expected ErrInvalidRequest, got %v", test.req, err)
+		}
+	}
+}
+
+func TestDailyValidatesBeforeRequest(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:0"), WithTokenSource(StaticTokenSource("test-token")))
+	if _, err := client.Daily(context.Background(), DailyQuotesRequest{}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+	if _, err := Daily("86970", "", "2022/09/29", ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
+
+func TestQuoteHasPrice(t *testing.T) {
+	var quotes DailyQuotes
+	err := json.Unmarshal([]byte(`{"daily_quotes":[
+		{"Code":"86970","Date":"2022-09-30","Open":2047.0,"High":2069.0,"Low":2035.0,"Close":2045.0,"Volume":2202500.0},
+		{"Code":"86970","Date":"2022-10-03","Open":null,"High":null,"Low":null,"Close":null,"Volume":null}
+	]}`), &quotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	traded, halted := quotes.DailyQuotes[0], quotes.DailyQuotes[1]
+	if !traded.HasPrice() || traded.Close != NewNullFloat64(2045) {
+		t.Errorf("unexpected quote %+v", traded)
+	}
+	if halted.HasPrice() || halted.Close.Valid || halted.Volume.Valid {
+		t.Errorf("prices of a halted day should be null: %+v", halted)
+	}
+	out, err := json.Marshal(halted.Close)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "null" {
+		t.Errorf("unexpected JSON %s", out)
+	}
+}
+
+func TestMorningQuotes(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prices/prices_am" || r.URL.Query().Has("code") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if r.URL.Query().Get("pagination_key") == "" {
+			w.Write([]byte(`{"prices_am":[{"Date":"2023-03-20","Code":"39400","MorningOpen":232.0,"MorningHigh":244.0,"MorningLow":232.0,"MorningClose":240.0,"MorningVolume":52600.0,"MorningTurnoverValue":12518800.0}],"pagination_key":"next"}`))
+			return
+		}
+		w.Write([]byte(`{"prices_am":[{"Date":"2023-03-20","Code":"39410","MorningOpen":null,"MorningHigh":null,"MorningLow":null,"MorningClose":null,"MorningVolume":null,"MorningTurnoverValue":null}]}`))
+	})
+
+	quotes, err := client.MorningQuotes(context.Background(), MorningQuotesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 2 {
+		t.Fatalf("expected two quotes, got %d", len(quotes))
+	}
+	if !quotes[0].HasPrice() || quotes[0].MorningClose != NewNullFloat64(240) {
+		t.Errorf("unexpected quote %+v", quotes[0])
+	}
+	if quotes[1].HasPrice() {
+		t.Errorf("quote without trades should have no price: %+v", quotes[1])
+	}
+}
@@ -0,0 +1,81 @@
+package jquants_api_go
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// TopixCode is the index code of TOPIX.
+const TopixCode = "0000"
+
+// IndexPrice is the daily price of an index.
+type IndexPrice struct {
+	Date  Date    `json:"Date"`
+	Code  string  `json:"Code"`
+	Open  float64 `json:"Open"`
+	High  float64 `json:"High"`
+	Low   float64 `json:"Low"`
+	Close float64 `json:"Close"`
+}
+
+var topixEndpoint = endpoint[IndexPrice]{"/indices/topix", "topix"}
+
+var indicesEndpoint = endpoint[IndexPrice]{"/indices", "indices"}
+
+// TopixRequest limits the TOPIX prices to a range of days. Both ends are
+// optional.
+type TopixRequest struct {
+	From time.Time
+	To   time.Time
+}
+
+// Validate reports invalid parameters.
+func (r TopixRequest) Validate() error {
+	return validateDateRange(time.Time{}, r.From, r.To)
+}
+
+func (r TopixRequest) query() url.Values {
+	query := url.Values{}
+	setDate(query, "from", r.From)
+	setDate(query, "to", r.To)
+	return query
+}
+
+// Topix returns the daily TOPIX prices selected by req. The Code of the
+// returned prices is TopixCode.
+func (c *Client) Topix(ctx context.Context, req TopixRequest) ([]IndexPrice, error) {
+	prices, err := topixEndpoint.list(ctx, c, req)
+	for i := range prices {
+		prices[i].Code = TopixCode
+	}
+	return prices, err
+}
+
+// IndicesRequest selects the index prices to fetch. Either Code or Date
+// must be set: Date alone returns all indices on that day, Code alone the
+// whole history of the index, optionally narrowed with From and/or To.
+type IndicesRequest struct {
+	Code string
+	Date time.Time
+	From time.Time
+	To   time.Time
+}
+
+// Validate reports invalid parameter combinations. Index codes are not
+// issue codes, so unlike validateCodeOrDate the code is passed on as is.
+func (r IndicesRequest) Validate() error {
+	if err := requireCodeOrDate(r.Code, r.Date); err != nil {
+		return err
+	}
+	return validateDateRange(r.Date, r.From, r.To)
+}
+
+func (r IndicesRequest) query() url.Values {
+	return codeOrDateQuery(r.Code, r.Date, r.From, r.To)
+}
+
+// Indices returns the daily index prices selected by req.
+func (c *Client) Indices(ctx context.Context, req IndicesRequest) ([]IndexPrice, error) {
+	return indicesEndpoint.list(ctx, c, req)
+}
@@ -0,0 +1,52 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTopix(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/indices/topix" || r.URL.Query().Get("from") != "20220601" || r.URL.Query().Has("to") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"topix":[{"Date":"2022-06-28","Open":1885.52,"High":1907.38,"Low":1885.32,"Close":1907.38}]}`))
+	})
+
+	prices, err := client.Topix(context.Background(), TopixRequest{From: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].Code != TopixCode || prices[0].Close != 1907.38 || prices[0].Date != NewDate(2022, time.June, 28) {
+		t.Errorf("unexpected prices %+v", prices)
+	}
+}
+
+func TestIndices(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/indices" || r.URL.Query().Get("code") != "0028" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if r.URL.Query().Get("pagination_key") == "" {
+			w.Write([]byte(`{"indices":[{"Date":"2023-12-01","Code":"0028","Open":1199.18,"High":1202.58,"Low":1195.01,"Close":1200.17}],"pagination_key":"next"}`))
+			return
+		}
+		w.Write([]byte(`{"indices":[{"Date":"2023-12-04","Code":"0028","Open":1195.6,"High":1197.2,"Low":1180.9,"Close":1181.3}]}`))
+	})
+
+	prices, err := client.Indices(context.Background(), IndicesRequest{Code: "0028"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 || prices[1].Date != NewDate(2023, time.December, 4) {
+		t.Errorf("unexpected prices %+v", prices)
+	}
+
+	_, err = client.Indices(context.Background(), IndicesRequest{From: time.Now()})
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
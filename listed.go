@@ -0,0 +1,54 @@
+package jquants_api_go
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// ListedInfo describes a listed issue as of Date.
+type ListedInfo struct {
+	Date               Date   `json:"Date"`
+	Code               string `json:"Code"`
+	CompanyName        string `json:"CompanyName"`
+	CompanyNameEnglish string `json:"CompanyNameEnglish"`
+	Sector17Code       string `json:"Sector17Code"`
+	Sector17CodeName   string `json:"Sector17CodeName"`
+	Sector33Code       string `json:"Sector33Code"`
+	Sector33CodeName   string `json:"Sector33CodeName"`
+	ScaleCategory      string `json:"ScaleCategory"`
+	MarketCode         string `json:"MarketCode"`
+	MarketCodeName     string `json:"MarketCodeName"`
+	// MarginCode and MarginCodeName are only provided on the premium plan.
+	MarginCode     string `json:"MarginCode,omitempty"`
+	MarginCodeName string `json:"MarginCodeName,omitempty"`
+}
+
+var listedInfoEndpoint = endpoint[ListedInfo]{"/listed/info", "info"}
+
+// ListedInfoRequest selects the issues to describe. Without Code all issues
+// are returned, without Date the information is as of today.
+type ListedInfoRequest struct {
+	Code string
+	Date time.Time
+}
+
+// Validate reports invalid parameters.
+func (r ListedInfoRequest) Validate() error {
+	if r.Code != "" {
+		return validateCode(r.Code)
+	}
+	return nil
+}
+
+func (r ListedInfoRequest) query() url.Values {
+	query := url.Values{}
+	setString(query, "code", r.Code)
+	setDate(query, "date", r.Date)
+	return query
+}
+
+// ListedInfo returns the listed issues selected by req.
+func (c *Client) ListedInfo(ctx context.Context, req ListedInfoRequest) ([]ListedInfo, error) {
+	return listedInfoEndpoint.list(ctx, c, req)
+}
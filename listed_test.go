@@ -0,0 +1,36 @@
+package jquants_api_go
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListedInfo(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/listed/info" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("code") != "86970" || query.Get("date") != "20220930" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		if query.Get("pagination_key") == "" {
+			w.Write([]byte(`{"info":[],"pagination_key":"next"}`))
+			return
+		}
+		w.Write([]byte(`{"info":[{"Date":"2022-09-30","Code":"86970","CompanyName":"日本取引所グループ","CompanyNameEnglish":"Japan Exchange Group,Inc.","Sector17Code":"16","Sector17CodeName":"金融（除く銀行）","Sector33Code":"7200","Sector33CodeName":"その他金融業","ScaleCategory":"TOPIX Large70","MarketCode":"0111","MarketCodeName":"プライム"}]}`))
+	})
+
+	info, err := client.ListedInfo(context.Background(), ListedInfoRequest{Code: "86970", Date: time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info) != 1 {
+		t.Fatalf("expected one issue, got %d", len(info))
+	}
+	if info[0].CompanyNameEnglish != "Japan Exchange Group,Inc." || info[0].Sector33Code != "7200" || info[0].Date != NewDate(2022, time.September, 30) {
+		t.Errorf("unexpected info %+v", info[0])
+	}
+}
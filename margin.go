@@ -0,0 +1,121 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WeeklyMarginInterest is the margin trading outstanding of an issue at the
+// end of a week, in shares. Negotiable and standardized margin trading add
+// up to the totals.
+type WeeklyMarginInterest struct {
+	Date                               Date    `json:"Date"`
+	Code                               string  `json:"Code"`
+	ShortMarginTradeVolume             float64 `json:"ShortMarginTradeVolume"`
+	LongMarginTradeVolume              float64 `json:"LongMarginTradeVolume"`
+	ShortNegotiableMarginTradeVolume   float64 `json:"ShortNegotiableMarginTradeVolume"`
+	LongNegotiableMarginTradeVolume    float64 `json:"LongNegotiableMarginTradeVolume"`
+	ShortStandardizedMarginTradeVolume float64 `json:"ShortStandardizedMarginTradeVolume"`
+	LongStandardizedMarginTradeVolume  float64 `json:"LongStandardizedMarginTradeVolume"`
+	// IssueType is 1 for margin trading issues, 2 for loan issues and 3
+	// for other issues.
+	IssueType string `json:"IssueType"`
+}
+
+var weeklyMarginInterestEndpoint = endpoint[WeeklyMarginInterest]{"/markets/weekly_margin_interest", "weekly_margin_interest"}
+
+// MarginInterestRequest selects margin balances. Either Code or Date must
+// be set: Date alone returns all issues on that day, Code alone the whole
+// history of the issue, optionally narrowed with From and/or To.
+type MarginInterestRequest struct {
+	Code string
+	Date time.Time
+	From time.Time
+	To   time.Time
+}
+
+// Validate reports invalid parameter combinations.
+func (r MarginInterestRequest) Validate() error {
+	return validateCodeOrDate(r.Code, r.Date, r.From, r.To)
+}
+
+func (r MarginInterestRequest) query() url.Values {
+	return codeOrDateQuery(r.Code, r.Date, r.From, r.To)
+}
+
+// WeeklyMarginInterest returns the weekly margin balances selected by req.
+func (c *Client) WeeklyMarginInterest(ctx context.Context, req MarginInterestRequest) ([]WeeklyMarginInterest, error) {
+	return weeklyMarginInterestEndpoint.list(ctx, c, req)
+}
+
+// Flag is a yes/no value J-Quants encodes as "1" and "0".
+type Flag bool
+
+// UnmarshalJSON accepts "1"/"0", 1/0 and true/false.
+func (f *Flag) UnmarshalJSON(buf []byte) error {
+	switch string(buf) {
+	case `"1"`, `1`, `true`:
+		*f = true
+	case `"0"`, `0`, `false`, `""`, `null`:
+		*f = false
+	default:
+		return fmt.Errorf("jquants: invalid flag %s", buf)
+	}
+	return nil
+}
+
+// MarshalJSON encodes f as "1" or "0".
+func (f Flag) MarshalJSON() ([]byte, error) {
+	if f {
+		return json.Marshal("1")
+	}
+	return json.Marshal("0")
+}
+
+// PublishReason tells why the daily margin balance of an issue is published.
+type PublishReason struct {
+	Restricted          Flag `json:"Restricted"`
+	DailyPublication    Flag `json:"DailyPublication"`
+	Monitoring          Flag `json:"Monitoring"`
+	RestrictedByJSF     Flag `json:"RestrictedByJSF"`
+	PrecautionByJSF     Flag `json:"PrecautionByJSF"`
+	UnclearOrSecOnAlert Flag `json:"UnclearOrSecOnAlert"`
+}
+
+// DailyMarginInterest is the margin trading outstanding of an issue that is
+// subject to daily publication, in shares. Ratios are in percent.
+type DailyMarginInterest struct {
+	PublishedDate   Date          `json:"PublishedDate"`
+	Code            string        `json:"Code"`
+	ApplicationDate Date          `json:"ApplicationDate"`
+	PublishReason   PublishReason `json:"PublishReason"`
+
+	ShortMarginOutstanding                        NullFloat64 `json:"ShortMarginOutstanding"`
+	DailyChangeShortMarginOutstanding             NullFloat64 `json:"DailyChangeShortMarginOutstanding"`
+	ShortMarginOutstandingListedShareRatio        NullFloat64 `json:"ShortMarginOutstandingListedShareRatio"`
+	LongMarginOutstanding                         NullFloat64 `json:"LongMarginOutstanding"`
+	DailyChangeLongMarginOutstanding              NullFloat64 `json:"DailyChangeLongMarginOutstanding"`
+	LongMarginOutstandingListedShareRatio         NullFloat64 `json:"LongMarginOutstandingListedShareRatio"`
+	ShortLongRatio                                NullFloat64 `json:"ShortLongRatio"`
+	ShortNegotiableMarginOutstanding              NullFloat64 `json:"ShortNegotiableMarginOutstanding"`
+	DailyChangeShortNegotiableMarginOutstanding   NullFloat64 `json:"DailyChangeShortNegotiableMarginOutstanding"`
+	ShortStandardizedMarginOutstanding            NullFloat64 `json:"ShortStandardizedMarginOutstanding"`
+	DailyChangeShortStandardizedMarginOutstanding NullFloat64 `json:"DailyChangeShortStandardizedMarginOutstanding"`
+	LongNegotiableMarginOutstanding               NullFloat64 `json:"LongNegotiableMarginOutstanding"`
+	DailyChangeLongNegotiableMarginOutstanding    NullFloat64 `json:"DailyChangeLongNegotiableMarginOutstanding"`
+	LongStandardizedMarginOutstanding             NullFloat64 `json:"LongStandardizedMarginOutstanding"`
+	DailyChangeLongStandardizedMarginOutstanding  NullFloat64 `json:"DailyChangeLongStandardizedMarginOutstanding"`
+
+	TSEMarginBorrowingAndLendingRegulationClassification string `json:"TSEMarginBorrowingAndLendingRegulationClassification"`
+}
+
+var dailyMarginInterestEndpoint = endpoint[DailyMarginInterest]{"/markets/daily_margin_interest", "daily_margin_interest"}
+
+// DailyMarginInterest returns the daily margin balances selected by req.
+// Dates refer to the publication date.
+func (c *Client) DailyMarginInterest(ctx context.Context, req MarginInterestRequest) ([]DailyMarginInterest, error) {
+	return dailyMarginInterestEndpoint.list(ctx, c, req)
+}
@@ -0,0 +1,55 @@
+package jquants_api_go
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWeeklyMarginInterest(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets/weekly_margin_interest" || r.URL.Query().Get("code") != "86970" || r.URL.Query().Get("from") != "20230201" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"weekly_margin_interest":[{"Date":"2023-02-17","Code":"86970","ShortMarginTradeVolume":4800.0,"LongMarginTradeVolume":39700.0,"ShortNegotiableMarginTradeVolume":600.0,"LongNegotiableMarginTradeVolume":1500.0,"ShortStandardizedMarginTradeVolume":4200.0,"LongStandardizedMarginTradeVolume":38200.0,"IssueType":"2"}]}`))
+	})
+
+	interest, err := client.WeeklyMarginInterest(context.Background(), MarginInterestRequest{
+		Code: "86970",
+		From: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interest) != 1 || interest[0].LongStandardizedMarginTradeVolume != 38200 || interest[0].Date != NewDate(2023, time.February, 17) {
+		t.Errorf("unexpected interest %+v", interest)
+	}
+}
+
+func TestDailyMarginInterest(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets/daily_margin_interest" || r.URL.Query().Get("date") != "20240208" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"daily_margin_interest":[{"PublishedDate":"2024-02-08","Code":"13260","ApplicationDate":"2024-02-07","PublishReason":{"Restricted":"0","DailyPublication":"0","Monitoring":"0","RestrictedByJSF":"0","PrecautionByJSF":"1","UnclearOrSecOnAlert":"0"},"ShortMarginOutstanding":11.0,"DailyChangeShortMarginOutstanding":0.0,"ShortMarginOutstandingListedShareRatio":"*","LongMarginOutstanding":676.0,"DailyChangeLongMarginOutstanding":-20.0,"LongMarginOutstandingListedShareRatio":0.01,"ShortLongRatio":1.6,"ShortNegotiableMarginOutstanding":0.0,"DailyChangeShortNegotiableMarginOutstanding":0.0,"ShortStandardizedMarginOutstanding":11.0,"DailyChangeShortStandardizedMarginOutstanding":0.0,"LongNegotiableMarginOutstanding":192.0,"DailyChangeLongNegotiableMarginOutstanding":-20.0,"LongStandardizedMarginOutstanding":484.0,"DailyChangeLongStandardizedMarginOutstanding":0.0,"TSEMarginBorrowingAndLendingRegulationClassification":"001"}]}`))
+	})
+
+	interest, err := client.DailyMarginInterest(context.Background(), MarginInterestRequest{Date: time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interest) != 1 {
+		t.Fatalf("expected one balance, got %d", len(interest))
+	}
+	balance := interest[0]
+	if !balance.PublishReason.PrecautionByJSF || balance.PublishReason.Restricted {
+		t.Errorf("unexpected publish reason %+v", balance.PublishReason)
+	}
+	if balance.ShortMarginOutstandingListedShareRatio.Valid {
+		t.Errorf("a ratio of * should be null")
+	}
+	if balance.LongMarginOutstanding != NewNullFloat64(676) || balance.DailyChangeLongMarginOutstanding != NewNullFloat64(-20) {
+		t.Errorf("unexpected balance %+v", balance)
+	}
+}
@@ -0,0 +1,158 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// Market sections used by the trading by investor type statistics.
+const (
+	SectionTSE1st      = "TSE1st"
+	SectionTSE2nd      = "TSE2nd"
+	SectionTSEMothers  = "TSEMothers"
+	SectionTSEJASDAQ   = "TSEJASDAQ"
+	SectionTSEPrime    = "TSEPrime"
+	SectionTSEStandard = "TSEStandard"
+	SectionTSEGrowth   = "TSEGrowth"
+	SectionTokyoNagoya = "TokyoNagoya"
+)
+
+// InvestorTrading is the weekly trading value of one type of investor, in
+// thousands of yen.
+type InvestorTrading struct {
+	Sales     float64 `json:"Sales"`
+	Purchases float64 `json:"Purchases"`
+	Total     float64 `json:"Total"`
+	Balance   float64 `json:"Balance"`
+}
+
+// TradingByType breaks down the trading of a market section during one week
+// by type of investor. J-Quants publishes it at /markets/trades_spec.
+type TradingByType struct {
+	PublishedDate Date   `json:"PublishedDate"`
+	StartDate     Date   `json:"StartDate"`
+	EndDate       Date   `json:"EndDate"`
+	Section       string `json:"Section"`
+
+	Proprietary                InvestorTrading `json:"-" flatten:"Proprietary"`
+	Brokerage                  InvestorTrading `json:"-" flatten:"Brokerage"`
+	Total                      InvestorTrading `json:"-" flatten:"Total"`
+	Individuals                InvestorTrading `json:"-" flatten:"Individuals"`
+	Foreigners                 InvestorTrading `json:"-" flatten:"Foreigners"`
+	SecuritiesCos              InvestorTrading `json:"-" flatten:"SecuritiesCos"`
+	InvestmentTrusts           InvestorTrading `json:"-" flatten:"InvestmentTrusts"`
+	BusinessCos                InvestorTrading `json:"-" flatten:"BusinessCos"`
+	OtherCos                   InvestorTrading `json:"-" flatten:"OtherCos"`
+	InsuranceCos               InvestorTrading `json:"-" flatten:"InsuranceCos"`
+	CityBKsRegionalBKsEtc      InvestorTrading `json:"-" flatten:"CityBKsRegionalBKsEtc"`
+	TrustBanks                 InvestorTrading `json:"-" flatten:"TrustBanks"`
+	OtherFinancialInstitutions InvestorTrading `json:"-" flatten:"OtherFinancialInstitutions"`
+}
+
+// UnmarshalJSON decodes the flat J-Quants keys such as ForeignersBalance.
+func (t *TradingByType) UnmarshalJSON(data []byte) error {
+	type plain TradingByType
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	return unflatten(data, t)
+}
+
+// MarshalJSON encodes t with the flat J-Quants keys.
+func (t TradingByType) MarshalJSON() ([]byte, error) {
+	type plain TradingByType
+	data, err := json.Marshal(plain(t))
+	if err != nil {
+		return nil, err
+	}
+	return flatten(data, t)
+}
+
+var tradesSpecEndpoint = endpoint[TradingByType]{"/markets/trades_spec", "trades_spec"}
+
+// TradingByTypeRequest selects the weeks to fetch. All fields are optional;
+// From and To refer to the publication date.
+type TradingByTypeRequest struct {
+	Section string
+	From    time.Time
+	To      time.Time
+}
+
+// Validate reports invalid parameters.
+func (r TradingByTypeRequest) Validate() error {
+	return validateDateRange(time.Time{}, r.From, r.To)
+}
+
+func (r TradingByTypeRequest) query() url.Values {
+	query := url.Values{}
+	setString(query, "section", r.Section)
+	setDate(query, "from", r.From)
+	setDate(query, "to", r.To)
+	return query
+}
+
+// TradingByType returns the trading by type of investor selected by req.
+func (c *Client) TradingByType(ctx context.Context, req TradingByTypeRequest) ([]TradingByType, error) {
+	return tradesSpecEndpoint.list(ctx, c, req)
+}
+
+// Breakdown splits the trading of an issue on Date by the kind of order.
+// Values are in yen, volumes in shares. Sales are split into long sales,
+// short sales not backed by margin transactions and margin sales opening or
+// closing a position; purchases likewise, without the short category.
+type Breakdown struct {
+	Date                         Date    `json:"Date"`
+	Code                         string  `json:"Code"`
+	LongSellValue                float64 `json:"LongSellValue"`
+	ShortSellWithoutMarginValue  float64 `json:"ShortSellWithoutMarginValue"`
+	MarginSellNewValue           float64 `json:"MarginSellNewValue"`
+	MarginSellCloseValue         float64 `json:"MarginSellCloseValue"`
+	LongBuyValue                 float64 `json:"LongBuyValue"`
+	MarginBuyNewValue            float64 `json:"MarginBuyNewValue"`
+	MarginBuyCloseValue          float64 `json:"MarginBuyCloseValue"`
+	LongSellVolume               float64 `json:"LongSellVolume"`
+	ShortSellWithoutMarginVolume float64 `json:"ShortSellWithoutMarginVolume"`
+	MarginSellNewVolume          float64 `json:"MarginSellNewVolume"`
+	MarginSellCloseVolume        float64 `json:"MarginSellCloseVolume"`
+	LongBuyVolume                float64 `json:"LongBuyVolume"`
+	MarginBuyNewVolume           float64 `json:"MarginBuyNewVolume"`
+	MarginBuyCloseVolume         float64 `json:"MarginBuyCloseVolume"`
+}
+
+// SellVolume returns the number of shares sold.
+func (b Breakdown) SellVolume() float64 {
+	return b.LongSellVolume + b.ShortSellWithoutMarginVolume + b.MarginSellNewVolume + b.MarginSellCloseVolume
+}
+
+// BuyVolume returns the number of shares bought.
+func (b Breakdown) BuyVolume() float64 {
+	return b.LongBuyVolume + b.MarginBuyNewVolume + b.MarginBuyCloseVolume
+}
+
+var breakdownEndpoint = endpoint[Breakdown]{"/markets/breakdown", "breakdown"}
+
+// BreakdownRequest selects breakdowns. Either Code or Date must be set: Date
+// alone returns all issues on that day, Code alone the whole history of the
+// issue, optionally narrowed with From and/or To.
+type BreakdownRequest struct {
+	Code string
+	Date time.Time
+	From time.Time
+	To   time.Time
+}
+
+// Validate reports invalid parameter combinations.
+func (r BreakdownRequest) Validate() error {
+	return validateCodeOrDate(r.Code, r.Date, r.From, r.To)
+}
+
+func (r BreakdownRequest) query() url.Values {
+	return codeOrDateQuery(r.Code, r.Date, r.From, r.To)
+}
+
+// Breakdown returns the trading breakdowns selected by req.
+func (c *Client) Breakdown(ctx context.Context, req BreakdownRequest) ([]Breakdown, error) {
+	return breakdownEndpoint.list(ctx, c, req)
+}
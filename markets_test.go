@@ -0,0 +1,86 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testTradesSpec = `{"PublishedDate":"2017-01-13","StartDate":"2017-01-04","EndDate":"2017-01-06","Section":"TSE1st",` +
+	`"ProprietarySales":1311271004,"ProprietaryPurchases":1453326508,"ProprietaryTotal":2764597512,"ProprietaryBalance":142055504,` +
+	`"IndividualsSales":1401711615,"IndividualsPurchases":1161801155,"IndividualsTotal":2563512770,"IndividualsBalance":-239910460,` +
+	`"ForeignersSales":5094891735,"ForeignersPurchases":5141329442,"ForeignersTotal":10236221177,"ForeignersBalance":46437707}`
+
+func TestTradingByType(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets/trades_spec" || r.URL.Query().Get("section") != SectionTSE1st || r.URL.Query().Get("to") != "20170131" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"trades_spec":[` + testTradesSpec + `]}`))
+	})
+
+	trading, err := client.TradingByType(context.Background(), TradingByTypeRequest{
+		Section: SectionTSE1st,
+		To:      time.Date(2017, 1, 31, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trading) != 1 {
+		t.Fatalf("expected one week, got %d", len(trading))
+	}
+	week := trading[0]
+	if week.EndDate != NewDate(2017, time.January, 6) || week.Section != SectionTSE1st {
+		t.Errorf("unexpected week %+v", week)
+	}
+	if week.Foreigners.Balance != 46437707 || week.Individuals.Sales != 1401711615 || week.Proprietary.Total != 2764597512 {
+		t.Errorf("unexpected breakdown %+v", week)
+	}
+}
+
+func TestTradingByTypeJSON(t *testing.T) {
+	var week TradingByType
+	if err := json.Unmarshal([]byte(testTradesSpec), &week); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(week)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(encoded, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["ForeignersBalance"] != 46437707.0 || raw["Section"] != SectionTSE1st {
+		t.Errorf("unexpected encoding %s", encoded)
+	}
+	var again TradingByType
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatal(err)
+	}
+	if again != week {
+		t.Errorf("round trip changed %+v into %+v", week, again)
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets/breakdown" || r.URL.Query().Get("code") != "13010" || r.URL.Query().Get("from") != "20150401" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"breakdown":[{"Date":"2015-04-01","Code":"13010","LongSellValue":115164000.0,"ShortSellWithoutMarginValue":93561000.0,"MarginSellNewValue":6412000.0,"MarginSellCloseValue":23009000.0,"LongBuyValue":185114000.0,"MarginBuyNewValue":35568000.0,"MarginBuyCloseValue":17464000.0,"LongSellVolume":415000.0,"ShortSellWithoutMarginVolume":337000.0,"MarginSellNewVolume":23000.0,"MarginSellCloseVolume":83000.0,"LongBuyVolume":667000.0,"MarginBuyNewVolume":128000.0,"MarginBuyCloseVolume":63000.0}]}`))
+	})
+
+	breakdown, err := client.Breakdown(context.Background(), BreakdownRequest{Code: "13010", From: time.Date(2015, 4, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breakdown) != 1 {
+		t.Fatalf("expected one breakdown, got %d", len(breakdown))
+	}
+	if b := breakdown[0]; b.SellVolume() != 858000 || b.BuyVolume() != 858000 || b.ShortSellWithoutMarginValue != 93561000 {
+		t.Errorf("unexpected breakdown %+v", b)
+	}
+}
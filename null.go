@@ -0,0 +1,87 @@
+package jquants_api_go
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NullFloat64 is a number J-Quants may report as null, e.g. the prices of a
+// day on which an issue did not trade. Valid is false for null values.
+//
+// Some endpoints encode numbers as strings and use "", "-" or "*" for
+// missing values; both forms are accepted when decoding.
+type NullFloat64 struct {
+	Float64 float64
+	Valid   bool
+}
+
+// NewNullFloat64 returns a valid NullFloat64 holding v.
+func NewNullFloat64(v float64) NullFloat64 {
+	return NullFloat64{Float64: v, Valid: true}
+}
+
+// Or returns the value, or fallback when it is null.
+func (n NullFloat64) Or(fallback float64) float64 {
+	if !n.Valid {
+		return fallback
+	}
+	return n.Float64
+}
+
+// String formats the value, or returns "null".
+func (n NullFloat64) String() string {
+	if !n.Valid {
+		return "null"
+	}
+	return strconv.FormatFloat(n.Float64, 'f', -1, 64)
+}
+
+// MarshalJSON encodes the value as a JSON number or null.
+func (n NullFloat64) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Float64)
+}
+
+// UnmarshalJSON accepts a JSON number, a numeric string or null.
+func (n *NullFloat64) UnmarshalJSON(buf []byte) error {
+	if string(buf) == "null" {
+		*n = NullFloat64{}
+		return nil
+	}
+	if len(buf) > 0 && buf[0] == '"' {
+		var s string
+		if err := json.Unmarshal(buf, &s); err != nil {
+			return err
+		}
+		parsed, err := ParseNullFloat64(s)
+		if err != nil {
+			return err
+		}
+		*n = parsed
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return fmt.Errorf("jquants: invalid number %s: %w", buf, err)
+	}
+	*n = NewNullFloat64(v)
+	return nil
+}
+
+// ParseNullFloat64 parses a string-encoded number. An empty string, "-" or
+// "*" yields null.
+func ParseNullFloat64(s string) (NullFloat64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "-" || s == "*" {
+		return NullFloat64{}, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return NullFloat64{}, fmt.Errorf("jquants: invalid number %q", s)
+	}
+	return NewNullFloat64(v), nil
+}
@@ -0,0 +1,87 @@
+package jquants_api_go
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// validateCode checks that code looks like a listed issue code: four
+// characters, or five when the check digit is included. Codes issued since
+// 2024 may contain upper case letters, e.g. "130A".
+func validateCode(code string) error {
+	if len(code) != 4 && len(code) != 5 {
+		return fmt.Errorf("%w: code %q must have 4 or 5 characters", ErrInvalidRequest, code)
+	}
+	for _, r := range code {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') {
+			return fmt.Errorf("%w: code %q must consist of digits and upper case letters", ErrInvalidRequest, code)
+		}
+	}
+	return nil
+}
+
+// validateDateRange checks that date and from/to are not combined and that
+// the range is not reversed.
+func validateDateRange(date, from, to time.Time) error {
+	if !date.IsZero() && (!from.IsZero() || !to.IsZero()) {
+		return fmt.Errorf("%w: Date cannot be combined with From or To", ErrInvalidRequest)
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return fmt.Errorf("%w: From %s is after To %s", ErrInvalidRequest, formatDate(from), formatDate(to))
+	}
+	return nil
+}
+
+// validateCodeOrDate checks the common code/date/from/to parameters: either
+// an issue code or a date is required, and a range cannot be combined with
+// a date.
+func validateCodeOrDate(code string, date, from, to time.Time) error {
+	if err := requireCodeOrDate(code, date); err != nil {
+		return err
+	}
+	if code != "" {
+		if err := validateCode(code); err != nil {
+			return err
+		}
+	}
+	return validateDateRange(date, from, to)
+}
+
+// requireCodeOrDate checks that at least one of code and date is set.
+func requireCodeOrDate(code string, date time.Time) error {
+	if code == "" && date.IsZero() {
+		return fmt.Errorf("%w: either Code or Date is required", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// codeOrDateQuery builds the query for the common code/date/from/to
+// parameters.
+func codeOrDateQuery(code string, date, from, to time.Time) url.Values {
+	query := url.Values{}
+	setString(query, "code", code)
+	setDate(query, "date", date)
+	setDate(query, "from", from)
+	setDate(query, "to", to)
+	return query
+}
+
+// formatDate formats t the way J-Quants expects dates in queries.
+func formatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+// setDate adds a date parameter to query unless t is zero.
+func setDate(query url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		query.Set(key, formatDate(t))
+	}
+}
+
+// setString adds a parameter to query unless value is empty.
+func setString(query url.Values, key string, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,99 @@
+package jquants_api_go
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit is a request rate enforced on the client side. Up to Burst
+// requests are sent at once, after which requests are spaced to keep to
+// RequestsPerSecond.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// Rate limits of the J-Quants subscription plans, in requests per minute as
+// published by J-Quants. Requests are spaced evenly so that no minute
+// exceeds the limit.
+var (
+	FreePlan     = RateLimit{RequestsPerSecond: 5.0 / 60, Burst: 1}
+	LightPlan    = RateLimit{RequestsPerSecond: 60.0 / 60, Burst: 1}
+	StandardPlan = RateLimit{RequestsPerSecond: 120.0 / 60, Burst: 1}
+	PremiumPlan  = RateLimit{RequestsPerSecond: 500.0 / 60, Burst: 1}
+)
+
+// WithRateLimit makes all requests of the client, including token
+// refreshes and retries, share one rate limit. Requests wait for their
+// turn until their context is done. By default, or with a zero rate,
+// requests are not limited.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Client) {
+		c.limiter = nil
+		if limit.RequestsPerSecond > 0 {
+			c.limiter = newRateLimiter(limit, time.Now)
+		}
+	}
+}
+
+// rateLimiter is a token bucket. Every request takes a token; tokens are
+// added at the configured rate up to the burst size.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limit RateLimit, now func() time.Time) *rateLimiter {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: limit.RequestsPerSecond, burst: burst, now: now, tokens: burst, last: now()}
+}
+
+// reserve takes a token and returns how long to wait before using it. The
+// bucket may go into debt, which queues concurrent callers in order.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a token taken by a request that was not sent.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Date(2023, 1, 4, 9, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(RateLimit{RequestsPerSecond: 2, Burst: 2}, func() time.Time { return now })
+
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if got := limiter.reserve(); got != want {
+			t.Errorf("reservation %d waits %v, want %v", i, got, want)
+		}
+	}
+
+	// an hour later the queued requests have been sent, and the bucket holds
+	// no more than Burst tokens
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if got := limiter.reserve(); got != 0 {
+			t.Errorf("reservation after an hour waits %v", got)
+		}
+	}
+	if got := limiter.reserve(); got != 500*time.Millisecond {
+		t.Errorf("burst exceeded, waits %v", got)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	limiter := newRateLimiter(RateLimit{RequestsPerSecond: 0.001, Burst: 1}, time.Now)
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if limiter.tokens < -0.01 {
+		t.Errorf("a cancelled wait should give its token back, have %v", limiter.tokens)
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var sent []time.Time
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent = append(sent, time.Now())
+		mu.Unlock()
+		w.Write([]byte(`{"daily_quotes":[]}`))
+	}, WithRateLimit(RateLimit{RequestsPerSecond: 100, Burst: 1}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Daily(context.Background(), testDailyRequest); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(sent) != 5 {
+		t.Fatalf("expected 5 requests, got %d", len(sent))
+	}
+	first, last := sent[0], sent[0]
+	for _, s := range sent {
+		if s.Before(first) {
+			first = s
+		}
+		if s.After(last) {
+			last = s
+		}
+	}
+	// four requests had to wait 10ms after the one before
+	if elapsed := last.Sub(first); elapsed < 35*time.Millisecond {
+		t.Errorf("requests were not spaced out, took %v", elapsed)
+	}
+}
@@ -0,0 +1,114 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries GET requests that failed
+// with 429 Too Many Requests, a 5xx status or a network error. Other
+// requests, including the token requests made to authenticate a GET, are
+// never retried.
+//
+// The n-th retry waits BaseDelay * 2^(n-1), at most MaxDelay. Jitter
+// randomly shortens each wait by up to that fraction so that concurrent
+// clients do not retry in lockstep. A Retry-After header sent by the API
+// takes precedence over the computed wait.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one. Zero
+	// or one disables retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Jitter is a fraction between 0 and 1; other values are clamped to that
+	// range.
+	Jitter float64
+	// OnRetry, if set, is called before waiting for a retry, with the
+	// number of the failed attempt, its error and the wait.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// DefaultRetryPolicy is a policy suited to long running jobs such as
+// backfills.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.5,
+}
+
+// WithRetryPolicy makes the client retry failed GET requests according to
+// policy. By default requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// delay returns how long to wait before retrying the failed attempt, and
+// whether to retry at all.
+func (p RetryPolicy) delay(ctx context.Context, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+		return 0, false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+	// a zero MaxDelay means no cap, short of overflowing
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay) && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if jitter := math.Min(p.Jitter, 1); jitter > 0 {
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay, true
+}
+
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	// the caller's context is checked separately, so a *url.Error here is a
+	// network failure or the client's own timeout
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
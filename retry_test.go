@@ -0,0 +1,199 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+func TestRetryServerErrors(t *testing.T) {
+	var retries []int
+	policy := testRetryPolicy
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		retries = append(retries, attempt)
+	}
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"daily_quotes":[{"Code":"86970","Date":"2022-09-30","Close":2020.0}]}`))
+		}
+	}, WithRetryPolicy(policy))
+
+	quotes, err := client.Daily(context.Background(), testDailyRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 1 || requests != 3 || len(retries) != 2 || retries[1] != 2 {
+		t.Errorf("unexpected result %v after %d requests and retries %v", quotes, requests, retries)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}, WithRetryPolicy(testRetryPolicy))
+
+	_, err := client.Daily(context.Background(), testDailyRequest)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected the last APIError, got %v", err)
+	}
+	if requests != testRetryPolicy.MaxAttempts {
+		t.Errorf("expected %d requests, got %d", testRetryPolicy.MaxAttempts, requests)
+	}
+}
+
+func TestRetryClientErrors(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}, WithRetryPolicy(testRetryPolicy))
+
+	if _, err := client.Daily(context.Background(), testDailyRequest); err == nil {
+		t.Errorf("expected an error")
+	}
+	if requests != 1 {
+		t.Errorf("client errors should not be retried, got %d requests", requests)
+	}
+}
+
+func TestRetryNetworkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	var retries int
+	policy := testRetryPolicy
+	policy.OnRetry = func(int, error, time.Duration) { retries++ }
+	client := NewClient(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource("test-token")), WithRetryPolicy(policy))
+
+	if _, err := client.Daily(context.Background(), testDailyRequest); err == nil {
+		t.Errorf("expected an error")
+	}
+	if retries != 2 {
+		t.Errorf("expected 2 retries, got %d", retries)
+	}
+}
+
+func TestRetryContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Hour,
+		OnRetry:     func(int, error, time.Duration) { cancel() },
+	}))
+
+	if _, err := client.Daily(ctx, testDailyRequest); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	unavailable := &APIError{StatusCode: http.StatusServiceUnavailable}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 9: 5 * time.Second} {
+		if delay, ok := policy.delay(ctx, attempt, unavailable); !ok || delay != want {
+			t.Errorf("delay after attempt %d = %v, want %v", attempt, delay, want)
+		}
+	}
+	if _, ok := policy.delay(ctx, 10, unavailable); ok {
+		t.Errorf("the last attempt should not be retried")
+	}
+
+	throttled := &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 42 * time.Second}
+	if delay, _ := policy.delay(ctx, 1, throttled); delay != 42*time.Second {
+		t.Errorf("Retry-After should be respected, got %v", delay)
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if delay, _ := policy.delay(ctx, 2, unavailable); delay < time.Second || delay > 2*time.Second {
+			t.Fatalf("jittered delay %v out of range", delay)
+		}
+	}
+	policy.Jitter = 3
+	for i := 0; i < 100; i++ {
+		if delay, _ := policy.delay(ctx, 2, unavailable); delay < 0 || delay > 2*time.Second {
+			t.Fatalf("jitter above 1 gave delay %v", delay)
+		}
+	}
+
+	uncapped := RetryPolicy{MaxAttempts: 100, BaseDelay: time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second} {
+		if delay, _ := uncapped.delay(ctx, attempt, unavailable); delay != want {
+			t.Errorf("uncapped delay after attempt %d = %v, want %v", attempt, delay, want)
+		}
+	}
+	if delay, _ := uncapped.delay(ctx, 99, unavailable); delay <= 0 {
+		t.Errorf("uncapped delay overflowed to %v", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 1, 4, 9, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"Wed, 04 Jan 2023 09:00:30 GMT": 30 * time.Second,
+		"Wed, 04 Jan 2023 08:00:00 GMT": 0,
+		"soon":                          0,
+	} {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestRetryTokenErrors(t *testing.T) {
+	var logins, refreshes, gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token/auth_user":
+			logins++
+		case "/token/auth_refresh":
+			refreshes++
+		default:
+			gets++
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	for _, test := range []struct {
+		name              string
+		refreshToken      RefreshToken
+		logins, refreshes int
+	}{
+		{"login", RefreshToken{}, 1, 0},
+		{"refresh", RefreshToken{RefreshToken: "refresh-1", IssuedAt: time.Now()}, 0, 1},
+	} {
+		logins, refreshes, gets = 0, 0, 0
+		client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+		store := MemoryTokenStore(Login{UserName: "user@example.com", Password: "secret"})
+		store.SaveRefreshToken(test.refreshToken)
+		client.tokens = NewRefreshingTokenSource(client, store)
+
+		var apiErr *APIError
+		if _, err := client.Daily(context.Background(), testDailyRequest); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("%s: expected the token request's APIError, got %v", test.name, err)
+		}
+		if logins != test.logins || refreshes != test.refreshes || gets != 0 {
+			t.Errorf("%s: token requests should not be retried, got %d logins, %d refreshes and %d GETs", test.name, logins, refreshes, gets)
+		}
+	}
+}
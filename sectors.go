@@ -0,0 +1,258 @@
+package jquants_api_go
+
+import (
+	"context"
+	"time"
+)
+
+// Sector17 is a TOPIX-17 sector.
+type Sector17 struct {
+	Code        string
+	Name        string
+	NameEnglish string
+}
+
+// Sector33 is a TSE 33-industry sector.
+type Sector33 struct {
+	Code        string
+	Name        string
+	NameEnglish string
+}
+
+// MarketSegment is a TSE market segment, including the segments in use
+// before the market restructuring of April 2022.
+type MarketSegment struct {
+	Code        string
+	Name        string
+	NameEnglish string
+}
+
+// The master lists as documented by J-Quants. The API only returns the
+// Japanese names, alongside the codes of each listed issue.
+var (
+	sector17List = []Sector17{
+		{"1", "食品", "Foods"},
+		{"2", "エネルギー資源", "Energy Resources"},
+		{"3", "建設・資材", "Construction & Materials"},
+		{"4", "素材・化学", "Raw Materials & Chemicals"},
+		{"5", "医薬品", "Pharmaceutical"},
+		{"6", "自動車・輸送機", "Automobiles & Transportation Equipment"},
+		{"7", "鉄鋼・非鉄", "Steel & Nonferrous Metals"},
+		{"8", "機械", "Machinery"},
+		{"9", "電機・精密", "Electric Appliances & Precision Instruments"},
+		{"10", "情報通信・サービスその他", "IT & Services, Others"},
+		{"11", "電力・ガス", "Electric Power & Gas"},
+		{"12", "運輸・物流", "Transportation & Logistics"},
+		{"13", "商社・卸売", "Commercial & Wholesale Trade"},
+		{"14", "小売", "Retail Trade"},
+		{"15", "銀行", "Banks"},
+		{"16", "金融（除く銀行）", "Financials (ex Banks)"},
+		{"17", "不動産", "Real Estate"},
+		{"99", "その他", "Other"},
+	}
+	sector33List = []Sector33{
+		{"0050", "水産・農林業", "Fishery, Agriculture & Forestry"},
+		{"1050", "鉱業", "Mining"},
+		{"2050", "建設業", "Construction"},
+		{"3050", "食料品", "Foods"},
+		{"3100", "繊維製品", "Textiles & Apparels"},
+		{"3150", "パルプ・紙", "Pulp & Paper"},
+		{"3200", "化学", "Chemicals"},
+		{"3250", "医薬品", "Pharmaceutical"},
+		{"3300", "石油・石炭製品", "Oil & Coal Products"},
+		{"3350", "ゴム製品", "Rubber Products"},
+		{"3400", "ガラス・土石製品", "Glass & Ceramics Products"},
+		{"3450", "鉄鋼", "Iron & Steel"},
+		{"3500", "非鉄金属", "Nonferrous Metals"},
+		{"3550", "金属製品", "Metal Products"},
+		{"3600", "機械", "Machinery"},
+		{"3650", "電気機器", "Electric Appliances"},
+		{"3700", "輸送用機器", "Transportation Equipment"},
+		{"3750", "精密機器", "Precision Instruments"},
+		{"3800", "その他製品", "Other Products"},
+		{"4050", "電気・ガス業", "Electric Power & Gas"},
+		{"5050", "陸運業", "Land Transportation"},
+		{"5100", "海運業", "Marine Transportation"},
+		{"5150", "空運業", "Air Transportation"},
+		{"5200", "倉庫・運輸関連業", "Warehousing & Harbor Transportation Services"},
+		{"5250", "情報・通信業", "Information & Communication"},
+		{"6050", "卸売業", "Wholesale Trade"},
+		{"6100", "小売業", "Retail Trade"},
+		{"7050", "銀行業", "Banks"},
+		{"7100", "証券、商品先物取引業", "Securities & Commodity Futures"},
+		{"7150", "保険業", "Insurance"},
+		{"7200", "その他金融業", "Other Financing Business"},
+		{"8050", "不動産業", "Real Estate"},
+		{"9050", "サービス業", "Services"},
+		{"9999", "その他", "Other"},
+	}
+	marketSegmentList = []MarketSegment{
+		{"0101", "東証一部", "1st Section"},
+		{"0102", "東証二部", "2nd Section"},
+		{"0104", "マザーズ", "Mothers"},
+		{"0105", "TOKYO PRO MARKET", "TOKYO PRO MARKET"},
+		{"0106", "JASDAQ スタンダード", "JASDAQ Standard"},
+		{"0107", "JASDAQ グロース", "JASDAQ Growth"},
+		{"0109", "その他", "Others"},
+		{"0111", "プライム", "Prime"},
+		{"0112", "スタンダード", "Standard"},
+		{"0113", "グロース", "Growth"},
+	}
+)
+
+// Sector17List returns all TOPIX-17 sectors.
+func Sector17List() []Sector17 {
+	return append([]Sector17(nil), sector17List...)
+}
+
+// Sector33List returns all 33-industry sectors.
+func Sector33List() []Sector33 {
+	return append([]Sector33(nil), sector33List...)
+}
+
+// MarketSegmentList returns all market segments.
+func MarketSegmentList() []MarketSegment {
+	return append([]MarketSegment(nil), marketSegmentList...)
+}
+
+// LookupSector17 returns the TOPIX-17 sector with the given code.
+func LookupSector17(code string) (Sector17, bool) {
+	for _, s := range sector17List {
+		if s.Code == code {
+			return s, true
+		}
+	}
+	return Sector17{}, false
+}
+
+// LookupSector33 returns the 33-industry sector with the given code.
+func LookupSector33(code string) (Sector33, bool) {
+	for _, s := range sector33List {
+		if s.Code == code {
+			return s, true
+		}
+	}
+	return Sector33{}, false
+}
+
+// LookupMarketSegment returns the market segment with the given code.
+func LookupMarketSegment(code string) (MarketSegment, bool) {
+	for _, m := range marketSegmentList {
+		if m.Code == code {
+			return m, true
+		}
+	}
+	return MarketSegment{}, false
+}
+
+// Sector17 returns the TOPIX-17 sector of the issue. The Japanese name is
+// the one returned by the API; codes missing from the master list have no
+// English name.
+func (i ListedInfo) Sector17() Sector17 {
+	s, _ := LookupSector17(i.Sector17Code)
+	s.Code = i.Sector17Code
+	if i.Sector17CodeName != "" {
+		s.Name = i.Sector17CodeName
+	}
+	return s
+}
+
+// Sector33 returns the 33-industry sector of the issue, named like Sector17.
+func (i ListedInfo) Sector33() Sector33 {
+	s, _ := LookupSector33(i.Sector33Code)
+	s.Code = i.Sector33Code
+	if i.Sector33CodeName != "" {
+		s.Name = i.Sector33CodeName
+	}
+	return s
+}
+
+// MarketSegment returns the market segment of the issue, named like
+// Sector17.
+func (i ListedInfo) MarketSegment() MarketSegment {
+	m, _ := LookupMarketSegment(i.MarketCode)
+	m.Code = i.MarketCode
+	if i.MarketCodeName != "" {
+		m.Name = i.MarketCodeName
+	}
+	return m
+}
+
+// MasterData resolves issue, sector and market codes without further API
+// calls. Sector and market names come from the fetched issues where
+// available and from the embedded master lists otherwise.
+type MasterData struct {
+	issues   map[string]ListedInfo
+	sector17 map[string]Sector17
+	sector33 map[string]Sector33
+	markets  map[string]MarketSegment
+}
+
+// NewMasterData builds master data from the given issues.
+func NewMasterData(info []ListedInfo) *MasterData {
+	md := &MasterData{
+		issues:   make(map[string]ListedInfo, len(info)),
+		sector17: make(map[string]Sector17, len(sector17List)),
+		sector33: make(map[string]Sector33, len(sector33List)),
+		markets:  make(map[string]MarketSegment, len(marketSegmentList)),
+	}
+	for _, s := range sector17List {
+		md.sector17[s.Code] = s
+	}
+	for _, s := range sector33List {
+		md.sector33[s.Code] = s
+	}
+	for _, m := range marketSegmentList {
+		md.markets[m.Code] = m
+	}
+	for _, i := range info {
+		md.issues[i.Code] = i
+		if i.Sector17Code != "" {
+			md.sector17[i.Sector17Code] = i.Sector17()
+		}
+		if i.Sector33Code != "" {
+			md.sector33[i.Sector33Code] = i.Sector33()
+		}
+		if i.MarketCode != "" {
+			md.markets[i.MarketCode] = i.MarketSegment()
+		}
+	}
+	return md
+}
+
+// MasterData fetches all issues listed on date, or today when date is zero.
+// When the request fails the returned master data is still usable: it
+// resolves sector and market codes from the embedded master lists, but knows
+// no issues.
+func (c *Client) MasterData(ctx context.Context, date time.Time) (*MasterData, error) {
+	info, err := c.ListedInfo(ctx, ListedInfoRequest{Date: date})
+	if err != nil {
+		return NewMasterData(nil), err
+	}
+	return NewMasterData(info), nil
+}
+
+// Issue returns the listed issue with the given code, as found in ListedInfo
+// and Quote.
+func (md *MasterData) Issue(code string) (ListedInfo, bool) {
+	i, ok := md.issues[code]
+	return i, ok
+}
+
+// Sector17 returns the TOPIX-17 sector with the given code.
+func (md *MasterData) Sector17(code string) (Sector17, bool) {
+	s, ok := md.sector17[code]
+	return s, ok
+}
+
+// Sector33 returns the 33-industry sector with the given code.
+func (md *MasterData) Sector33(code string) (Sector33, bool) {
+	s, ok := md.sector33[code]
+	return s, ok
+}
+
+// MarketSegment returns the market segment with the given code.
+func (md *MasterData) MarketSegment(code string) (MarketSegment, bool) {
+	m, ok := md.markets[code]
+	return m, ok
+}
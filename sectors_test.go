@@ -0,0 +1,78 @@
+package jquants_api_go
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLookupSectors(t *testing.T) {
+	if s, ok := LookupSector33("7200"); !ok || s.Name != "その他金融業" || s.NameEnglish != "Other Financing Business" {
+		t.Errorf("unexpected sector %+v", s)
+	}
+	if m, ok := LookupMarketSegment("0111"); !ok || m.NameEnglish != "Prime" {
+		t.Errorf("unexpected market segment %+v", m)
+	}
+	if _, ok := LookupSector17("42"); ok {
+		t.Errorf("unknown codes should not be found")
+	}
+	if len(Sector17List()) != 18 || len(Sector33List()) != 34 {
+		t.Errorf("incomplete master lists")
+	}
+}
+
+func TestListedInfoSectors(t *testing.T) {
+	info := ListedInfo{Sector17Code: "16", Sector17CodeName: "金融(除く銀行)", Sector33Code: "7200", MarketCode: "0111"}
+	if s := info.Sector17(); s.Name != "金融(除く銀行)" || s.NameEnglish != "Financials (ex Banks)" {
+		t.Errorf("unexpected sector %+v", s)
+	}
+	if s := info.Sector33(); s.Name != "その他金融業" {
+		t.Errorf("the master list should name sectors missing a name, got %+v", s)
+	}
+	if m := info.MarketSegment(); m.Code != "0111" || m.NameEnglish != "Prime" {
+		t.Errorf("unexpected market segment %+v", m)
+	}
+}
+
+func TestMasterData(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/listed/info" || r.URL.Query().Get("date") != "20220930" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"info":[{"Date":"2022-09-30","Code":"86970","CompanyName":"日本取引所グループ","CompanyNameEnglish":"Japan Exchange Group,Inc.","Sector17Code":"16","Sector17CodeName":"金融（除く銀行）","Sector33Code":"7200","Sector33CodeName":"その他金融業","ScaleCategory":"TOPIX Large70","MarketCode":"0120","MarketCodeName":"新市場"}]}`))
+	})
+
+	md, err := client.MasterData(context.Background(), time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	quote := Quote{Code: "86970"}
+	issue, ok := md.Issue(quote.Code)
+	if !ok || issue.Sector33().NameEnglish != "Other Financing Business" {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+	if m, ok := md.MarketSegment("0120"); !ok || m.Name != "新市場" {
+		t.Errorf("codes returned by the API should be known, got %+v", m)
+	}
+	if s, ok := md.Sector17("1"); !ok || s.NameEnglish != "Foods" {
+		t.Errorf("unexpected sector %+v", s)
+	}
+}
+
+func TestMasterDataFallback(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	md, err := client.MasterData(context.Background(), time.Time{})
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+	if s, ok := md.Sector33("3650"); !ok || s.NameEnglish != "Electric Appliances" {
+		t.Errorf("unexpected sector %+v", s)
+	}
+	if _, ok := md.Issue("86970"); ok {
+		t.Errorf("no issues should be known")
+	}
+}
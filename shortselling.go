@@ -0,0 +1,120 @@
+package jquants_api_go
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ShortSelling is the daily selling value of a 33-sector, in yen, split by
+// whether short sales were subject to the price restriction.
+type ShortSelling struct {
+	Date                                         Date    `json:"Date"`
+	Sector33Code                                 string  `json:"Sector33Code"`
+	SellingExcludingShortSellingTurnoverValue    float64 `json:"SellingExcludingShortSellingTurnoverValue"`
+	ShortSellingWithRestrictionsTurnoverValue    float64 `json:"ShortSellingWithRestrictionsTurnoverValue"`
+	ShortSellingWithoutRestrictionsTurnoverValue float64 `json:"ShortSellingWithoutRestrictionsTurnoverValue"`
+}
+
+// ShortSellingRatio returns the share of short sales in the selling value.
+func (s ShortSelling) ShortSellingRatio() float64 {
+	short := s.ShortSellingWithRestrictionsTurnoverValue + s.ShortSellingWithoutRestrictionsTurnoverValue
+	total := s.SellingExcludingShortSellingTurnoverValue + short
+	if total == 0 {
+		return 0
+	}
+	return short / total
+}
+
+var shortSellingEndpoint = endpoint[ShortSelling]{"/markets/short_selling", "short_selling"}
+
+// ShortSellingRequest selects short selling values. Either Sector33Code or
+// Date must be set: Date alone returns all sectors on that day,
+// Sector33Code alone the whole history of the sector, optionally narrowed
+// with From and/or To.
+type ShortSellingRequest struct {
+	Sector33Code string
+	Date         time.Time
+	From         time.Time
+	To           time.Time
+}
+
+// Validate reports invalid parameter combinations.
+func (r ShortSellingRequest) Validate() error {
+	if r.Sector33Code == "" && r.Date.IsZero() {
+		return fmt.Errorf("%w: either Sector33Code or Date is required", ErrInvalidRequest)
+	}
+	return validateDateRange(r.Date, r.From, r.To)
+}
+
+func (r ShortSellingRequest) query() url.Values {
+	query := codeOrDateQuery("", r.Date, r.From, r.To)
+	setString(query, "sector33code", r.Sector33Code)
+	return query
+}
+
+// ShortSelling returns the short selling values selected by req.
+func (c *Client) ShortSelling(ctx context.Context, req ShortSellingRequest) ([]ShortSelling, error) {
+	return shortSellingEndpoint.list(ctx, c, req)
+}
+
+// ShortSellingPosition is a reported short position of 0.5% or more of the
+// shares outstanding of an issue.
+type ShortSellingPosition struct {
+	DisclosedDate                            Date        `json:"DisclosedDate"`
+	CalculatedDate                           Date        `json:"CalculatedDate"`
+	Code                                     string      `json:"Code"`
+	ShortSellerName                          string      `json:"ShortSellerName"`
+	ShortSellerAddress                       string      `json:"ShortSellerAddress"`
+	DiscretionaryInvestmentContractorName    string      `json:"DiscretionaryInvestmentContractorName"`
+	DiscretionaryInvestmentContractorAddress string      `json:"DiscretionaryInvestmentContractorAddress"`
+	InvestmentFundName                       string      `json:"InvestmentFundName"`
+	ShortPositionsToSharesOutstandingRatio   float64     `json:"ShortPositionsToSharesOutstandingRatio"`
+	ShortPositionsInSharesNumber             float64     `json:"ShortPositionsInSharesNumber"`
+	ShortPositionsInTradingUnitsNumber       float64     `json:"ShortPositionsInTradingUnitsNumber"`
+	CalculationInPreviousReportingDate       Date        `json:"CalculationInPreviousReportingDate"`
+	ShortPositionsInPreviousReportingRatio   NullFloat64 `json:"ShortPositionsInPreviousReportingRatio"`
+	Notes                                    string      `json:"Notes"`
+}
+
+var shortSellingPositionsEndpoint = endpoint[ShortSellingPosition]{"/markets/short_selling_positions", "short_selling_positions"}
+
+// ShortSellingPositionsRequest selects short position reports. One of Code,
+// Date or CalculatedDate must be set. Date, From and To refer to the
+// disclosure date.
+type ShortSellingPositionsRequest struct {
+	Code           string
+	Date           time.Time
+	From           time.Time
+	To             time.Time
+	CalculatedDate time.Time
+}
+
+// Validate reports invalid parameter combinations.
+func (r ShortSellingPositionsRequest) Validate() error {
+	if r.Code == "" && r.Date.IsZero() && r.CalculatedDate.IsZero() {
+		return fmt.Errorf("%w: one of Code, Date or CalculatedDate is required", ErrInvalidRequest)
+	}
+	if r.Code != "" {
+		if err := validateCode(r.Code); err != nil {
+			return err
+		}
+	}
+	return validateDateRange(r.Date, r.From, r.To)
+}
+
+func (r ShortSellingPositionsRequest) query() url.Values {
+	query := url.Values{}
+	setString(query, "code", r.Code)
+	setDate(query, "disclosed_date", r.Date)
+	setDate(query, "disclosed_date_from", r.From)
+	setDate(query, "disclosed_date_to", r.To)
+	setDate(query, "calculated_date", r.CalculatedDate)
+	return query
+}
+
+// ShortSellingPositions returns the short position reports selected by req.
+func (c *Client) ShortSellingPositions(ctx context.Context, req ShortSellingPositionsRequest) ([]ShortSellingPosition, error) {
+	return shortSellingPositionsEndpoint.list(ctx, c, req)
+}
@@ -0,0 +1,56 @@
+package jquants_api_go
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestShortSelling(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets/short_selling" || r.URL.Query().Get("sector33code") != "0050" || r.URL.Query().Has("code") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"short_selling":[{"Date":"2022-10-25","Sector33Code":"0050","SellingExcludingShortSellingTurnoverValue":600,"ShortSellingWithRestrictionsTurnoverValue":200,"ShortSellingWithoutRestrictionsTurnoverValue":200}]}`))
+	})
+
+	selling, err := client.ShortSelling(context.Background(), ShortSellingRequest{Sector33Code: "0050"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selling) != 1 || selling[0].Date != NewDate(2022, time.October, 25) {
+		t.Fatalf("unexpected short selling %+v", selling)
+	}
+	if ratio := selling[0].ShortSellingRatio(); ratio != 0.4 {
+		t.Errorf("unexpected short selling ratio %v", ratio)
+	}
+}
+
+func TestShortSellingPositions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/markets/short_selling_positions" || query.Get("code") != "13730" || query.Get("disclosed_date_from") != "20240801" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"short_selling_positions":[{"DisclosedDate":"2024-08-01","CalculatedDate":"2024-07-31","Code":"13730","ShortSellerName":"個人","ShortSellerAddress":"","DiscretionaryInvestmentContractorName":"","DiscretionaryInvestmentContractorAddress":"","InvestmentFundName":"","ShortPositionsToSharesOutstandingRatio":0.0053,"ShortPositionsInSharesNumber":140000,"ShortPositionsInTradingUnitsNumber":140000,"CalculationInPreviousReportingDate":"2024-07-22","ShortPositionsInPreviousReportingRatio":0.0043,"Notes":""}]}`))
+	})
+
+	positions, err := client.ShortSellingPositions(context.Background(), ShortSellingPositionsRequest{
+		Code: "13730",
+		From: time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("expected one position, got %d", len(positions))
+	}
+	position := positions[0]
+	if position.ShortPositionsToSharesOutstandingRatio != 0.0053 || position.ShortPositionsInPreviousReportingRatio != NewNullFloat64(0.0043) {
+		t.Errorf("unexpected position %+v", position)
+	}
+	if position.CalculationInPreviousReportingDate != NewDate(2024, time.July, 22) {
+		t.Errorf("unexpected previous reporting date %v", position.CalculationInPreviousReportingDate)
+	}
+}
@@ -0,0 +1,247 @@
+package jquants_api_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// IdTokenLifetime is how long an ID token stays valid after it was issued.
+	IdTokenLifetime = 24 * time.Hour
+	// RefreshTokenLifetime is how long a refresh token stays valid after it was issued.
+	RefreshTokenLifetime = 7 * 24 * time.Hour
+
+	// tokenExpiryMargin refreshes tokens a little before they actually expire.
+	tokenExpiryMargin = 5 * time.Minute
+)
+
+// TokenSource supplies the ID token sent with every API request.
+//
+// A TokenSource that also has an Invalidate(idToken string) method is told
+// when the API rejects a token with 401, after which the request is retried
+// once with a new token.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// tokenInvalidator is implemented by token sources that can replace a
+// token the API rejected.
+type tokenInvalidator interface {
+	Invalidate(idToken string)
+}
+
+// TokenSourceFunc adapts a function to the TokenSource interface.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource always returns the given ID token.
+func StaticTokenSource(idToken string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		return idToken, nil
+	})
+}
+
+// TokenStore persists the credentials and tokens of one account.
+type TokenStore interface {
+	Login() (Login, error)
+	RefreshToken() (RefreshToken, error)
+	SaveRefreshToken(RefreshToken) error
+	IdToken() (IdToken, error)
+	SaveIdToken(IdToken) error
+}
+
+// ConfigTokenStore keeps tokens in the local jquants configuration
+// directory, the same files used by PrepareLogin, GetRefreshToken and
+// GetIdToken.
+func ConfigTokenStore() TokenStore {
+	return configTokenStore{}
+}
+
+type configTokenStore struct{}
+
+func (configTokenStore) Login() (Login, error) {
+	return GetUser()
+}
+func (configTokenStore) RefreshToken() (RefreshToken, error) {
+	return ReadRefreshToken()
+}
+func (configTokenStore) SaveRefreshToken(rt RefreshToken) error {
+	return writeConfigEDN(REFRESH_TOKEN_FILE, &rt)
+}
+func (configTokenStore) IdToken() (IdToken, error) {
+	return ReadIdToken()
+}
+func (configTokenStore) SaveIdToken(it IdToken) error {
+	return writeConfigEDN(ID_TOKEN_FILE, &it)
+}
+
+// MemoryTokenStore keeps tokens in memory only, which is handy when running
+// several accounts in one process.
+func MemoryTokenStore(login Login) TokenStore {
+	return &memoryTokenStore{login: login}
+}
+
+type memoryTokenStore struct {
+	mu           sync.Mutex
+	login        Login
+	refreshToken RefreshToken
+	idToken      IdToken
+}
+
+func (s *memoryTokenStore) Login() (Login, error) {
+	return s.login, nil
+}
+func (s *memoryTokenStore) RefreshToken() (RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refreshToken, nil
+}
+func (s *memoryTokenStore) SaveRefreshToken(rt RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshToken = rt
+	return nil
+}
+func (s *memoryTokenStore) IdToken() (IdToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.idToken, nil
+}
+func (s *memoryTokenStore) SaveIdToken(it IdToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idToken = it
+	return nil
+}
+
+// RefreshingTokenSource hands out the stored ID token while it is fresh.
+// Close to expiry, or once the API rejected it, a new ID token is requested
+// with the stored refresh token; when that has expired as well the source
+// logs in again with the stored credentials. Concurrent callers share a
+// single in-flight refresh.
+type RefreshingTokenSource struct {
+	client *Client
+	store  TokenStore
+	now    func() time.Time
+
+	mu       sync.Mutex
+	idToken  IdToken
+	loaded   bool
+	inflight *tokenRefresh
+}
+
+type tokenRefresh struct {
+	done    chan struct{}
+	idToken IdToken
+	err     error
+}
+
+// NewRefreshingTokenSource returns a token source that uses client to
+// obtain new tokens and store to persist them.
+func NewRefreshingTokenSource(client *Client, store TokenStore) *RefreshingTokenSource {
+	return &RefreshingTokenSource{client: client, store: store, now: time.Now}
+}
+
+// Token returns a valid ID token, refreshing it if needed. Callers waiting
+// for a refresh started by another goroutine stop waiting when ctx is done.
+func (s *RefreshingTokenSource) Token(ctx context.Context) (string, error) {
+	for {
+		s.mu.Lock()
+		if !s.loaded {
+			// a missing or unreadable token simply triggers a refresh
+			s.idToken, _ = s.store.IdToken()
+			s.loaded = true
+		}
+		if s.fresh(s.idToken.IssuedAt, IdTokenLifetime) && s.idToken.IdToken != "" {
+			idToken := s.idToken.IdToken
+			s.mu.Unlock()
+			return idToken, nil
+		}
+		if call := s.inflight; call != nil {
+			s.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			if call.err != nil && ctx.Err() == nil && isContextError(call.err) {
+				// the goroutine that refreshed gave up, try again ourselves
+				continue
+			}
+			return call.idToken.IdToken, call.err
+		}
+		call := &tokenRefresh{done: make(chan struct{})}
+		s.inflight = call
+		s.mu.Unlock()
+
+		call.idToken, call.err = s.refresh(ctx)
+
+		s.mu.Lock()
+		s.inflight = nil
+		if call.err == nil {
+			s.idToken = call.idToken
+		}
+		s.mu.Unlock()
+		close(call.done)
+
+		return call.idToken.IdToken, call.err
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Invalidate drops idToken so that the next call to Token refreshes it.
+func (s *RefreshingTokenSource) Invalidate(idToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idToken.IdToken == idToken {
+		s.idToken = IdToken{}
+		s.loaded = true
+	}
+}
+
+// fresh reports whether a token issued at issuedAt is still usable. Tokens
+// saved by older versions have no issue time; they are used until the API
+// rejects them.
+func (s *RefreshingTokenSource) fresh(issuedAt time.Time, lifetime time.Duration) bool {
+	return issuedAt.IsZero() || s.now().Before(issuedAt.Add(lifetime-tokenExpiryMargin))
+}
+
+func (s *RefreshingTokenSource) refresh(ctx context.Context) (IdToken, error) {
+	rt, err := s.store.RefreshToken()
+	if err == nil && rt.RefreshToken != "" && s.fresh(rt.IssuedAt, RefreshTokenLifetime) {
+		it, err := s.client.RefreshIdToken(ctx, rt)
+		var apiErr *APIError
+		if err == nil {
+			return it, s.store.SaveIdToken(it)
+		} else if !errors.As(err, &apiErr) || apiErr.StatusCode >= http.StatusInternalServerError {
+			return it, err
+		}
+		// the refresh token was rejected, fall back to a full login
+	}
+
+	login, err := s.store.Login()
+	if err != nil {
+		return IdToken{}, err
+	}
+	rt, err = s.client.Authenticate(ctx, login)
+	if err != nil {
+		return IdToken{}, err
+	}
+	if err := s.store.SaveRefreshToken(rt); err != nil {
+		return IdToken{}, err
+	}
+	it, err := s.client.RefreshIdToken(ctx, rt)
+	if err != nil {
+		return it, err
+	}
+	return it, s.store.SaveIdToken(it)
+}
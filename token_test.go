@@ -0,0 +1,155 @@
+package jquants_api_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeAuthServer issues numbered tokens and only accepts the latest ID token.
+type fakeAuthServer struct {
+	*httptest.Server
+	logins    int32
+	refreshes int32
+	latest    atomic.Value
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	s := &fakeAuthServer{}
+	s.latest.Store("")
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token/auth_user":
+			n := atomic.AddInt32(&s.logins, 1)
+			json.NewEncoder(w).Encode(RefreshToken{RefreshToken: fmt.Sprintf("refresh-%d", n)})
+		case "/token/auth_refresh":
+			n := atomic.AddInt32(&s.refreshes, 1)
+			idToken := fmt.Sprintf("id-%d", n)
+			s.latest.Store(idToken)
+			json.NewEncoder(w).Encode(IdToken{IdToken: idToken})
+		case "/prices/daily_quotes":
+			if r.Header.Get("Authorization") != "Bearer "+s.latest.Load().(string) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"The incoming token is invalid or expired."}`))
+				return
+			}
+			w.Write([]byte(`{"daily_quotes":[]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	return s
+}
+
+func newRefreshingClient(server *fakeAuthServer) (*Client, *RefreshingTokenSource, TokenStore) {
+	client := NewClient(WithBaseURL(server.URL))
+	store := MemoryTokenStore(Login{UserName: "user@example.com", Password: "secret"})
+	tokens := NewRefreshingTokenSource(client, store)
+	client.tokens = tokens
+	return client, tokens, store
+}
+
+func TestRefreshingTokenSourceSharesRefresh(t *testing.T) {
+	server := newFakeAuthServer(t)
+	defer server.Close()
+	client, _, _ := newRefreshingClient(server)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Daily(context.Background(), testDailyRequest); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if server.logins != 1 || server.refreshes != 1 {
+		t.Errorf("expected a single login and refresh, got %d and %d", server.logins, server.refreshes)
+	}
+}
+
+func TestRefreshingTokenSourceExpiry(t *testing.T) {
+	server := newFakeAuthServer(t)
+	defer server.Close()
+	_, tokens, _ := newRefreshingClient(server)
+
+	now := time.Now()
+	tokens.now = func() time.Time { return now }
+	if _, err := tokens.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the ID token expired, the refresh token is still valid
+	now = now.Add(IdTokenLifetime)
+	idToken, err := tokens.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idToken != "id-2" || server.logins != 1 {
+		t.Errorf("expected a refresh without login, got %s after %d logins", idToken, server.logins)
+	}
+
+	// both tokens expired
+	now = now.Add(RefreshTokenLifetime)
+	idToken, err = tokens.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idToken != "id-3" || server.logins != 2 {
+		t.Errorf("expected a new login, got %s after %d logins", idToken, server.logins)
+	}
+}
+
+func TestRefreshingTokenSourceUnauthorized(t *testing.T) {
+	server := newFakeAuthServer(t)
+	defer server.Close()
+	client, _, store := newRefreshingClient(server)
+
+	// a token that looks fresh but is unknown to the server
+	store.SaveRefreshToken(RefreshToken{RefreshToken: "refresh-0", IssuedAt: time.Now()})
+	store.SaveIdToken(IdToken{IdToken: "revoked", IssuedAt: time.Now()})
+
+	if _, err := client.Daily(context.Background(), testDailyRequest); err != nil {
+		t.Fatal(err)
+	}
+	if server.refreshes != 1 {
+		t.Errorf("expected the rejected token to be refreshed, got %d refreshes", server.refreshes)
+	}
+}
+
+func TestRefreshingTokenSourceUnknownAge(t *testing.T) {
+	server := newFakeAuthServer(t)
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	// tokens saved before issue times were recorded, and no credentials
+	store := MemoryTokenStore(Login{})
+	store.SaveRefreshToken(RefreshToken{RefreshToken: "legacy-refresh"})
+	store.SaveIdToken(IdToken{IdToken: "legacy-id"})
+	client.tokens = NewRefreshingTokenSource(client, store)
+
+	server.latest.Store("legacy-id")
+	if _, err := client.Daily(context.Background(), testDailyRequest); err != nil {
+		t.Fatal(err)
+	}
+	if server.refreshes != 0 || server.logins != 0 {
+		t.Errorf("expected the stored ID token to be used, got %d refreshes and %d logins", server.refreshes, server.logins)
+	}
+
+	// the server no longer accepts the stored ID token
+	server.latest.Store("newer")
+	if _, err := client.Daily(context.Background(), testDailyRequest); err != nil {
+		t.Fatal(err)
+	}
+	if server.refreshes != 1 || server.logins != 0 {
+		t.Errorf("expected a refresh with the stored refresh token, got %d refreshes and %d logins", server.refreshes, server.logins)
+	}
+}